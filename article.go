@@ -0,0 +1,173 @@
+package cinii
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ArticleEndpoint は、CiNii Articles論文検索のOpenSearchのURI
+const ArticleEndpoint = "http://ci.nii.ac.jp/opensearch/search"
+
+// ArticleFeed はCiNii ArticlesのOpenSearchのAtom1.0レスポンス構造体
+type ArticleFeed struct {
+	XMLName      xml.Name       `xml:"http://www.w3.org/2005/Atom feed"`
+	Title        string         `xml:"http://www.w3.org/2005/Atom title"`
+	Links        []Link         `xml:"http://www.w3.org/2005/Atom link"`
+	ID           string         `xml:"http://www.w3.org/2005/Atom id"`
+	Updated      customTime     `xml:"http://www.w3.org/2005/Atom updated"`
+	TotalResults int            `xml:"http://a9.com/-/spec/opensearch/1.1/ totalResults"`
+	StartIndex   int            `xml:"http://a9.com/-/spec/opensearch/1.1/ startIndex"`
+	ItemsPerPage int            `xml:"http://a9.com/-/spec/opensearch/1.1/ itemsPerPage"`
+	Entries      []ArticleEntry `xml:"http://www.w3.org/2005/Atom entry"`
+
+	Warnings []string `xml:"-"` // 解析できずゼロ値とした日時等の警告
+}
+
+// collectWarnings は解析できなかった日時をWarningsに記録するメソッド
+func (f *ArticleFeed) collectWarnings() {
+	if err := f.Updated.err; err != nil {
+		f.Warnings = append(f.Warnings, "updated: "+err.Error())
+	}
+	for i := range f.Entries {
+		if err := f.Entries[i].Updated.err; err != nil {
+			f.Warnings = append(f.Warnings, fmt.Sprintf("entry %d: updated: %s", i+1, err))
+		}
+	}
+}
+
+// ArticleEntry はArticleFeedのエントリ構造体。タイトル、著者、ISSN等はEntryと共通
+type ArticleEntry struct {
+	Entry
+	JournalTitle string `xml:"http://prismstandard.org/namespaces/basic/2.0/ publicationName"`
+	Volume       string `xml:"http://prismstandard.org/namespaces/basic/2.0/ volume"`
+	Issue        string `xml:"http://prismstandard.org/namespaces/basic/2.0/ number"`
+	StartingPage string `xml:"http://prismstandard.org/namespaces/basic/2.0/ startingPage"`
+	EndingPage   string `xml:"http://prismstandard.org/namespaces/basic/2.0/ endingPage"`
+}
+
+// Pages は掲載ページを"12-34"の形式で返すメソッド
+func (e *ArticleEntry) Pages() (string, bool) {
+	start, end := strings.TrimSpace(e.StartingPage), strings.TrimSpace(e.EndingPage)
+	switch {
+	case len(start) == 0:
+		return end, len(end) > 0
+	case len(end) == 0 || end == start:
+		return start, true
+	}
+	return start + "-" + end, true
+}
+
+// ArticleSortOrder はCiNii ArticlesのOpenSearchの検索結果の並び順（sortorder）。
+// CiNii Booksとは値の意味が異なるため、SortOrderとは別の型とする
+type ArticleSortOrder int
+
+// CiNii Articlesの並び順の定数
+const (
+	ArticleSortDefault     ArticleSortOrder = iota // 既定の並び順（sortorderを送信しない）
+	ArticleByYearDesc                              // 出版年の新しい順
+	ArticleByYearAsc                               // 出版年の古い順
+	ArticleByTitleAsc                              // 論文名の昇順
+	ArticleByTitleDesc                             // 論文名の降順
+	ArticleByJournalAsc                            // 刊行物名の昇順
+	ArticleByJournalDesc                           // 刊行物名の降順
+	ArticleByCitationsDesc                         // 被引用件数の多い順
+)
+
+var articleSortOrderNames = []string{"default", "year_desc", "year_asc", "title_asc", "title_desc", "journal_asc", "journal_desc", "citations_desc"}
+
+// Stringerインターフェースの実装
+func (o ArticleSortOrder) String() string {
+	if o >= 0 && int(o) < len(articleSortOrderNames) {
+		return articleSortOrderNames[o]
+	}
+	return fmt.Sprintf("ArticleSortOrder(%d)", int(o))
+}
+
+// ArticleParams はCiNii ArticlesのOpenSearchの検索条件の構造体。ゼロ値の項目は送信しない
+type ArticleParams struct {
+	Q         string // フリーワード（q）
+	Title     string // 論文名（title）
+	Author    string // 著者名（author）
+	Journal   string // 刊行物名（journal）
+	ISSN      string // ISSN（issn）
+	Volume    string // 巻（volume）
+	Issue     string // 号（issue）
+	Page      string // ページ（page）
+	Publisher string // 出版者（publisher）
+	YearFrom  int    // 出版年の下限（year_from）
+	YearTo    int    // 出版年の上限（year_to）
+
+	Count     int              // 1ページの件数（count）
+	Start     int              // 取得する最初の結果の番号（start）
+	SortOrder ArticleSortOrder // 並び順（sortorder）
+
+	AppID string // appid。空の場合はClientのAppIDを使う
+}
+
+// Values はArticleParamsをOpenSearchのクエリパラメタに変換するメソッド
+func (p ArticleParams) Values() url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			q.Set(key, value)
+		}
+	}
+	setInt := func(key string, value int) {
+		if value > 0 {
+			q.Set(key, strconv.Itoa(value))
+		}
+	}
+	set("q", p.Q)
+	set("title", p.Title)
+	set("author", p.Author)
+	set("journal", p.Journal)
+	set("issn", p.ISSN)
+	set("volume", p.Volume)
+	set("issue", p.Issue)
+	set("page", p.Page)
+	set("publisher", p.Publisher)
+	setInt("year_from", p.YearFrom)
+	setInt("year_to", p.YearTo)
+	setInt("count", p.Count)
+	setInt("start", p.Start)
+	setInt("sortorder", int(p.SortOrder))
+	set("appid", p.AppID)
+	return q
+}
+
+// ArticleSearch はCiNii ArticlesをOpenSearchで検索する関数
+func ArticleSearch(q url.Values) (*ArticleFeed, error) {
+	return (*Client)(nil).ArticleSearch(context.Background(), q)
+}
+
+// ArticleSearch はCiNii ArticlesをOpenSearchで検索するメソッド。qにappidがなければClientのAppIDを付加する
+func (c *Client) ArticleSearch(ctx context.Context, q url.Values) (*ArticleFeed, error) {
+	body, err := c.openSearch(ctx, ArticleEndpoint, q)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return decodeArticleFeed(body)
+}
+
+// ParseArticleFeed はCiNii ArticlesのAtomFeedを含むbyte[]を受け取りArticleFeed構造体のポインタで返す関数
+func ParseArticleFeed(body []byte) (*ArticleFeed, error) {
+	return decodeArticleFeed(bytes.NewReader(body))
+}
+
+// decodeArticleFeed はCiNii ArticlesのAtomFeedを読み出すio.Readerを受け取り、ArticleFeed構造体のポインタで返す関数
+func decodeArticleFeed(r io.Reader) (*ArticleFeed, error) {
+	feed := &ArticleFeed{}
+	if err := newXMLDecoder(r).Decode(feed); err != nil {
+		return nil, err
+	}
+	feed.collectWarnings()
+	return feed, nil
+}
@@ -0,0 +1,44 @@
+package cinii
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArticleParamsValues(t *testing.T) {
+	tests := []struct {
+		order ArticleSortOrder
+		want  string
+	}{
+		{ArticleSortDefault, ""},
+		{ArticleByYearDesc, "1"},
+		{ArticleByJournalAsc, "5"},
+		{ArticleByCitationsDesc, "7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order.String(), func(t *testing.T) {
+			q := ArticleParams{Q: "猫", SortOrder: tt.order}.Values()
+			if got := q.Get("sortorder"); got != tt.want {
+				t.Errorf("sortorder = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArticleFeedWarnings(t *testing.T) {
+	const feed = `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:prism="http://prismstandard.org/namespaces/basic/2.0/">
+<updated>2024-01-01T00:00:00+09:00</updated>
+<entry><title>論文1</title><updated>not a date</updated><prism:publicationName>図書館雑誌</prism:publicationName></entry>
+<entry><title>論文2</title><updated>2024-01-01T00:00:00+09:00</updated></entry>
+</feed>`
+	f, err := ParseArticleFeed([]byte(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Entries) != 2 || f.Entries[0].JournalTitle != "図書館雑誌" {
+		t.Errorf("Entries = %+v", f.Entries)
+	}
+	if len(f.Warnings) != 1 || !strings.HasPrefix(f.Warnings[0], "entry 1: updated: ") {
+		t.Errorf("Warnings = %q", f.Warnings)
+	}
+}
@@ -0,0 +1,150 @@
+package cinii
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// AuthorEndpoint はCiNii Books著者のURI
+const AuthorEndpoint = "http://ci.nii.ac.jp/author"
+
+// AuthorRecord は著者典拠の構造体
+type AuthorRecord struct {
+	ID       string // CiNii著者ID (DA00000001)
+	URL      string
+	Name     string      // 統一形
+	Reading  string      // 統一形の読み
+	Variants []TextField // 異なり形。読みはLangを持つ
+	Dates    string      // 生没年等
+	Related  []string    // 他の典拠等のURI（owl:sameAs, rdfs:seeAlso）
+	Graph    Graph
+}
+
+// authorDatePredicates は生没年を表す述語
+var authorDatePredicates = []string{
+	nsCiNii + "date",
+	nsDC + "date",
+	nsDCTerms + "date",
+	"http://RDVocab.info/ElementsGr2/dateAssociatedWithThePerson",
+}
+
+// ParseAuthor は著者のRDF/XMLを含むbyte[]を受け取り、AuthorRecord構造体のポインタで返す関数
+func ParseAuthor(body []byte) (*AuthorRecord, error) {
+	g, err := parseGraph(body)
+	if err != nil {
+		return nil, err
+	}
+	return newAuthorRecord(g)
+}
+
+// newAuthorRecord はトリプルの集合から著者典拠を取り出す関数。
+// foaf:nameを持つ最初の主語を著者とする
+func newAuthorRecord(g Graph) (*AuthorRecord, error) {
+	names := g.Find("", nsFOAF+"name")
+	if len(names) == 0 {
+		return nil, fmt.Errorf("cinii: no author name")
+	}
+	subject := names[0].Subject
+	a := &AuthorRecord{URL: trimExtension(subject), ID: trimResourceURI(subject), Graph: g}
+
+	for _, t := range g.Find(subject, nsFOAF+"name") {
+		text := strings.TrimSpace(t.Object)
+		switch {
+		case len(text) == 0:
+		case isReadingLang(t.Lang) && len(a.Reading) == 0:
+			a.Reading = text
+		case !isReadingLang(t.Lang) && len(a.Name) == 0:
+			a.Name = text
+		default:
+			a.Variants = append(a.Variants, TextField{Lang: t.Lang, Text: text})
+		}
+	}
+	for _, pred := range authorDatePredicates {
+		if dates := g.Objects(subject, pred); len(dates) > 0 {
+			a.Dates = strings.TrimSpace(dates[0])
+			break
+		}
+	}
+	for _, pred := range []string{nsOWL + "sameAs", nsRDFS + "seeAlso"} {
+		for _, t := range g.Find(subject, pred) {
+			if !t.Literal && len(t.Object) > 0 {
+				a.Related = append(a.Related, t.Object)
+			}
+		}
+	}
+	return a, nil
+}
+
+// authorURL は著者IDまたは著者のURLを検証し、拡張子を除いた著者のURLを返す関数
+func authorURL(s string) (string, error) {
+	if !strings.Contains(s, "://") {
+		id := strings.ToUpper(strings.TrimSpace(s))
+		if !authorIDPattern.MatchString(id) {
+			return "", fmt.Errorf("cinii: invalid author ID: %q", s)
+		}
+		return AuthorEndpoint + "/" + id, nil
+	}
+	t, id, err := ParseResourceURL(s)
+	if err != nil {
+		return "", err
+	}
+	if t != ResourceAuthor {
+		return "", fmt.Errorf("cinii: not an author URL: %s", s)
+	}
+	return resourceURL(s, t, id)
+}
+
+// GetAuthor は著者IDまたは著者のURLを受け取り、著者典拠をAuthorRecord構造体のポインタで返すメソッド
+func (c *Client) GetAuthor(ctx context.Context, id string) (*AuthorRecord, error) {
+	u, err := authorURL(id)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.open(ctx, u+".rdf")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuthor(data)
+}
+
+// FetchAuthors はレコードの著者の著者典拠を取得し、Authors()と同じ順序で返すメソッド。
+// 著者IDを持たない著者は除く
+func (r *Record) FetchAuthors(ctx context.Context, client *Client) ([]*AuthorRecord, error) {
+	authors, ok := r.Authors()
+	if !ok {
+		return nil, nil
+	}
+	var ids []string
+	for _, author := range authors {
+		if len(author[2]) > 0 {
+			ids = append(ids, author[2])
+		}
+	}
+
+	ret := make([]*AuthorRecord, len(ids))
+	err := client.parallel(ctx, len(ids), func(ctx context.Context, i int) (err error) {
+		ret[i], err = client.GetAuthor(ctx, ids[i])
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// WorksByAuthor は著者IDを受け取り、その著者の書誌をOpenSearchでページ順にすべて検索したエントリの配列を返すメソッド
+func (c *Client) WorksByAuthor(ctx context.Context, id string) ([]Entry, error) {
+	u, err := authorURL(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.SearchAll(ctx, SearchParams{AuthorID: trimResourceURI(u), Count: MaxCount})
+}
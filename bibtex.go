@@ -0,0 +1,120 @@
+package cinii
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yearPattern は出版年中の西暦年のパターン
+var yearPattern = regexp.MustCompile(`[0-9]{4}`)
+
+// firstYear は出版年の文字列から最初の西暦年を返す関数。西暦年がない場合は和暦年を西暦年に変換する
+func firstYear(date string) string {
+	if year := yearPattern.FindString(date); len(year) > 0 {
+		return year
+	}
+	if years, ok := ParseYears(date); ok {
+		return strconv.Itoa(years.From)
+	}
+	return ""
+}
+
+// bibtexEscaper はBibTeXの特殊文字をエスケープするReplacer
+var bibtexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`%`, `\%`,
+	`&`, `\&`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// escapeBibTeX はBibTeXのフィールド値をエスケープする関数
+func escapeBibTeX(s string) string {
+	return bibtexEscaper.Replace(strings.TrimSpace(s))
+}
+
+// ToBibTeX はレコードをBibTeXのエントリに変換するメソッド。雑誌は@periodical、それ以外は@bookとする。
+// Descriptionのないレコードは空文字列を返す
+func (r *Record) ToBibTeX() string {
+	if len(r.Descriptions) == 0 {
+		return ""
+	}
+	d := r.Descriptions[0]
+
+	entryType := "book"
+	if r.Kind() == KindJournal {
+		entryType = "periodical"
+	}
+
+	// fieldsの値はエスケープ済みとする
+	var fields [][2]string
+	add := func(name, value string) {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			fields = append(fields, [2]string{name, escapeBibTeX(value)})
+		}
+	}
+
+	title, _ := r.TitleWithReading()
+	add("title", title.Title)
+	add("yomi", title.Reading)
+	if authors, ok := r.Authors(); ok {
+		// 団体名は姓名に分けられないよう{}で囲む
+		names := make([]string, len(authors))
+		for i, author := range authors {
+			name := ParseName(author[0])
+			names[i] = escapeBibTeX(name.Format(NameInverted))
+			if name.Corporate {
+				names[i] = "{" + names[i] + "}"
+			}
+		}
+		fields = append(fields, [2]string{"author", strings.Join(names, " and ")})
+	}
+	if publishers, ok := r.Publishers(); ok {
+		add("publisher", publishers[0].Name)
+		add("address", publishers[0].Place)
+	}
+	add("year", firstYear(d.Date))
+	add("edition", d.Edition)
+	add("language", d.Language)
+
+	var notes []string
+	if len(d.NCID) > 0 {
+		notes = append(notes, "NCID: "+d.NCID)
+	}
+	if isbns, ok := r.ISBNs(); ok {
+		values := make([]string, len(isbns))
+		for i, isbn := range isbns {
+			values[i] = string(isbn)
+		}
+		add("isbn", strings.Join(values, ", "))
+		notes = append(notes, "ISBN: "+strings.Join(values, ", "))
+	}
+	if issns, ok := r.ISSNs(); ok {
+		add("issn", strings.Join(issns, ", "))
+	}
+	add("note", strings.Join(notes, "; "))
+
+	key := d.NCID
+	if len(key) == 0 {
+		key = "cinii"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "@%s{%s,\n", entryType, key)
+	for i, field := range fields {
+		fmt.Fprintf(&sb, "  %s = {%s}", field[0], field[1])
+		if i < len(fields)-1 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
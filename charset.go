@@ -0,0 +1,145 @@
+package cinii
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// CharsetReader は文字コード名を受け取り、UTF-8に変換するio.Readerを返す関数。
+// レスポンスのContent-Type、BOM、XML宣言で指定された文字コードがUTF-8でない場合に呼び出される。
+//
+// 既定の実装が変換できるのはUTF-8、US-ASCII、ISO-8859-1、UTF-16のみで、
+// Shift_JIS、EUC-JP、ISO-2022-JPを含むそれ以外の文字コードはErrUnsupportedCharsetを返す。
+// 日本語の文字コードのレスポンスを読み込むには、標準ライブラリ以外のパッケージの変換を設定する。例えば
+//
+//	cinii.CharsetReader = charset.NewReaderLabel // golang.org/x/net/html/charset
+var CharsetReader = defaultCharsetReader
+
+// ErrUnsupportedCharset は既定のCharsetReaderが変換できない文字コードの場合のエラー
+var ErrUnsupportedCharset = errors.New("cinii: unsupported charset")
+
+// xmlEncodingPattern はXML宣言のencoding属性のパターン
+var xmlEncodingPattern = regexp.MustCompile(`^\s*<\?xml[^>]*?encoding\s*=\s*["']([^"']+)["']`)
+
+// newXMLDecoder はCharsetReaderを設定し、名前空間の別名を正規化するxml.Decoderを返す関数
+func newXMLDecoder(r io.Reader) *xml.Decoder {
+	d := xml.NewDecoder(r)
+	d.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		return CharsetReader(label, input)
+	}
+	return xml.NewTokenDecoder(nsNormalizer{d})
+}
+
+// utf8Reader はContent-Type、BOM、XML宣言から文字コードを判定し、UTF-8で読み出すio.Readerを返す関数
+func utf8Reader(r io.Reader, contentType string) (io.Reader, error) {
+	var label string
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		label = params["charset"]
+	}
+
+	br := bufio.NewReaderSize(r, peekSize)
+	head, _ := br.Peek(peekSize)
+	switch {
+	case bytes.HasPrefix(head, []byte{0xEF, 0xBB, 0xBF}):
+		br.Discard(3)
+		label = "utf-8"
+	case bytes.HasPrefix(head, []byte{0xFE, 0xFF}):
+		label = "utf-16be"
+	case bytes.HasPrefix(head, []byte{0xFF, 0xFE}):
+		label = "utf-16le"
+	case len(label) == 0:
+		if m := xmlEncodingPattern.FindSubmatch(head); m != nil {
+			label = string(m[1])
+		}
+	}
+
+	if isUTF8(label) {
+		return br, nil
+	}
+
+	cr, err := CharsetReader(label, br)
+	if err != nil {
+		return nil, err
+	}
+
+	// 変換後のデータに合わせてXML宣言の文字コードを書き換える
+	tr := bufio.NewReaderSize(cr, peekSize)
+	head, _ = tr.Peek(peekSize)
+	if m := xmlEncodingPattern.FindSubmatchIndex(head); m != nil {
+		prefix := append(append([]byte{}, head[:m[2]]...), "UTF-8"...)
+		tr.Discard(m[3])
+		return io.MultiReader(bytes.NewReader(prefix), tr), nil
+	}
+	return tr, nil
+}
+
+// peekSize は文字コード判定のために先読みするバイト数
+const peekSize = 1024
+
+// isUTF8 は文字コード名がUTF-8（またはその部分集合）か判定する関数
+func isUTF8(label string) bool {
+	switch strings.ToLower(strings.TrimSpace(label)) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return true
+	}
+	return false
+}
+
+// defaultCharsetReader はCharsetReaderの既定の実装。UTF-8、US-ASCII、ISO-8859-1、UTF-16以外はErrUnsupportedCharsetを返す
+func defaultCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(label)) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "latin1", "l1":
+		body, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		for _, b := range body {
+			buf.WriteRune(rune(b))
+		}
+		return &buf, nil
+	case "utf-16", "utf-16be", "utf-16le":
+		body, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(decodeUTF16(body, strings.ToLower(label) == "utf-16le")), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedCharset, label)
+}
+
+// decodeUTF16 はUTF-16のbyte[]をUTF-8に変換する関数。BOMがあればそれに従う
+func decodeUTF16(body []byte, little bool) []byte {
+	switch {
+	case bytes.HasPrefix(body, []byte{0xFE, 0xFF}):
+		body, little = body[2:], false
+	case bytes.HasPrefix(body, []byte{0xFF, 0xFE}):
+		body, little = body[2:], true
+	}
+	units := make([]uint16, len(body)/2)
+	for i := range units {
+		if little {
+			units[i] = uint16(body[2*i]) | uint16(body[2*i+1])<<8
+		} else {
+			units[i] = uint16(body[2*i])<<8 | uint16(body[2*i+1])
+		}
+	}
+	runes := utf16.Decode(units)
+	buf := make([]byte, 0, len(runes))
+	for _, r := range runes {
+		buf = utf8.AppendRune(buf, r)
+	}
+	return buf
+}
@@ -0,0 +1,44 @@
+package cinii
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestUTF8Reader(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		want        string
+	}{
+		{"utf-8", "<a>猫</a>", "application/rdf+xml; charset=UTF-8", "<a>猫</a>"},
+		{"latin1 declaration", "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><a>\xe9</a>", "", "<?xml version=\"1.0\" encoding=\"UTF-8\"?><a>é</a>"},
+		{"utf-16le bom", "\xff\xfe<\x00a\x00>\x00\x2b\x73<\x00/\x00a\x00>\x00", "", "<a>猫</a>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := utf8Reader(strings.NewReader(tt.body), tt.contentType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUTF8ReaderUnsupported(t *testing.T) {
+	for _, contentType := range []string{"text/xml; charset=Shift_JIS", "text/xml; charset=EUC-JP"} {
+		if _, err := utf8Reader(strings.NewReader("<a/>"), contentType); !errors.Is(err, ErrUnsupportedCharset) {
+			t.Errorf("%s: err = %v, want ErrUnsupportedCharset", contentType, err)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package cinii
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CitationStyle は参考文献の書式
+type CitationStyle int
+
+// 参考文献の書式の定数
+const (
+	StyleSIST02  CitationStyle = iota // 科学技術情報流通技術基準 SIST 02
+	StyleAPA                          // APA 7th
+	StyleMLA                          // MLA 9th
+	StyleChicago                      // Chicago 17th (notes-bibliography)
+)
+
+var citationStyleNames = []string{"SIST02", "APA", "MLA", "Chicago"}
+
+// Stringerインターフェースの実装
+func (s CitationStyle) String() string {
+	if s >= 0 && int(s) < len(citationStyleNames) {
+		return citationStyleNames[s]
+	}
+	return "Unknown"
+}
+
+// citation は参考文献の書式化に用いる項目の構造体
+type citation struct {
+	authors    []PersonalName
+	title      string
+	translated string // 英語のタイトル。本タイトルが英語でない場合のみ
+	edition    string
+	place      string
+	publisher  string
+	year       string
+	extent     string
+	series     []SeriesInfo
+	isbn       string
+}
+
+// hasLatin は文字列にラテン文字が含まれるか判定する関数
+func hasLatin(s string) bool {
+	for _, c := range s {
+		if unicode.In(c, unicode.Latin) {
+			return true
+		}
+	}
+	return false
+}
+
+// displayName はSIST02の著者名を返す関数。欧文名は"姓, 名"、和名は区切りを除いて姓名を続ける
+func displayName(n PersonalName) string {
+	if n.IsLatin() {
+		return n.Format(NameInverted)
+	}
+	return n.Format(NameNatural)
+}
+
+// initials は欧文の名を頭文字にする関数。"John Ronald"は"J. R."、"Jean-Paul"は"J.-P."とする
+func initials(given string) string {
+	words := strings.Fields(given)
+	for i, w := range words {
+		parts := strings.Split(w, "-")
+		for j, p := range parts {
+			if r := []rune(strings.TrimSuffix(p, ".")); len(r) > 0 {
+				parts[j] = string(r[0]) + "."
+			}
+		}
+		words[i] = strings.Join(parts, "-")
+	}
+	return strings.Join(words, " ")
+}
+
+// apaName はAPAの著者名を返す関数。欧文の個人名は"姓, 名の頭文字"、それ以外は"姓, 名"とする
+func apaName(n PersonalName) string {
+	if n.IsLatin() && !n.Corporate && len(n.Given) > 0 {
+		n.Given = initials(n.Given)
+	}
+	return n.Format(NameInverted)
+}
+
+// names はfirstで最初の著者名を、restで2人目以降の著者名を組み立てた配列を返すメソッド
+func (c citation) names(first, rest func(PersonalName) string) []string {
+	ret := make([]string, len(c.authors))
+	for i, n := range c.authors {
+		if i == 0 {
+			ret[i] = first(n)
+		} else {
+			ret[i] = rest(n)
+		}
+	}
+	return ret
+}
+
+// inverted は"姓, 名"の著者名を返す関数
+func inverted(n PersonalName) string {
+	return n.Format(NameInverted)
+}
+
+// natural は欧文名は"名 姓"、和名は"姓名"の著者名を返す関数
+func natural(n PersonalName) string {
+	return n.Format(NameNatural)
+}
+
+// newCitation はレコードから参考文献の項目を取り出す関数。タイトルの読みは用いない
+func newCitation(r *Record) citation {
+	d := r.Descriptions[0]
+	c := citation{
+		edition: strings.TrimSpace(d.Edition),
+		year:    firstYear(d.Date),
+	}
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			c.authors = append(c.authors, ParseName(author[0]))
+		}
+	} else if creator := strings.TrimSpace(d.Creator); len(creator) > 0 {
+		c.authors = []PersonalName{{Family: creator}}
+	}
+	title, _ := r.TitleWithReading()
+	c.title = title.Title
+	if !hasLatin(c.title) {
+		if en, ok := r.Title("en"); ok {
+			c.translated = en
+		}
+	}
+	if publishers, ok := r.Publishers(); ok {
+		c.place, c.publisher = publishers[0].Place, publishers[0].Name
+	}
+	if pd, ok := r.PhysicalDescription(); ok {
+		c.extent = pd.Extent
+	}
+	c.series, _ = r.SeriesInfo()
+	if isbns, ok := r.ISBNs(); ok {
+		c.isbn = string(isbns[0])
+	}
+	return c
+}
+
+// joinAuthors は著者名をsepで連結し、最後の著者の前にはlastを用いる関数
+func joinAuthors(names []string, sep, last string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], sep) + last + names[len(names)-1]
+}
+
+// terminate は文字列が句読点で終わっていなければ"."を付ける関数。空文字列はそのまま返す
+func terminate(s string) string {
+	if len(s) == 0 || strings.HasSuffix(s, ".") || strings.HasSuffix(s, "?") || strings.HasSuffix(s, "!") || strings.HasSuffix(s, "。") {
+		return s
+	}
+	return s + "."
+}
+
+// FormatCitation はレコードを指定した書式の参考文献の文字列に変換する関数。
+// 日本語のタイトルに英語のタイトル（dc:title xml:lang="en"）がある場合、SIST02以外の書式では角括弧で併記する
+func FormatCitation(r *Record, style CitationStyle) (string, error) {
+	if len(r.Descriptions) == 0 {
+		return "", fmt.Errorf("cinii: no description")
+	}
+	c := newCitation(r)
+
+	var parts []string
+	add := func(values ...string) {
+		for _, v := range values {
+			if v = strings.TrimSpace(v); len(v) > 0 {
+				parts = append(parts, v)
+			}
+		}
+	}
+	title := c.title
+	if len(c.translated) > 0 {
+		title += " [" + c.translated + "]"
+	}
+
+	switch style {
+	case StyleSIST02:
+		// 著者名. 書名. 版表示, 出版者, 出版年, ページ数, (シリーズ名, シリーズ番号), ISBN.
+		names := c.names(displayName, displayName)
+		var sb strings.Builder
+		if len(names) > 0 {
+			sb.WriteString(terminate(strings.Join(names, "; ")) + " ")
+		}
+		sb.WriteString(terminate(c.title))
+		add(c.edition, c.publisher, c.year, c.extent)
+		for _, s := range c.series {
+			if len(s.Volume) > 0 {
+				add("(" + s.Title + ", " + s.Volume + ")")
+			} else if len(s.Title) > 0 {
+				add("(" + s.Title + ")")
+			}
+		}
+		if len(c.isbn) > 0 {
+			add("ISBN " + c.isbn)
+		}
+		if len(parts) > 0 {
+			sb.WriteString(" " + terminate(strings.Join(parts, ", ")))
+		}
+		return sb.String(), nil
+
+	case StyleAPA:
+		// Author, A., & Author, B. (Year). Title [Translated title] (Edition). Publisher.
+		// 著者名はすべて"姓, 名の頭文字"とする
+		authors := joinAuthors(c.names(apaName, apaName), ", ", ", & ")
+		year := c.year
+		if len(year) == 0 {
+			year = "n.d."
+		}
+		if len(title) > 0 && len(c.edition) > 0 {
+			title += " (" + c.edition + ")"
+		}
+		add(terminate(authors), "("+year+").", terminate(title), terminate(c.publisher))
+		return strings.Join(parts, " "), nil
+
+	case StyleMLA:
+		// Author, and Author. Title [Translated title]. Edition, Publisher, Year.
+		// 最初の著者名のみ"姓, 名"とし、3人以上は最初の著者名とet al.とする
+		names := c.names(inverted, natural)
+		var authors string
+		switch len(names) {
+		case 0:
+		case 1, 2:
+			authors = joinAuthors(names, "", ", and ")
+		default:
+			authors = names[0] + ", et al"
+		}
+		add(terminate(authors), terminate(title))
+		var pub []string
+		for _, v := range []string{c.edition, c.publisher, c.year} {
+			if len(v) > 0 {
+				pub = append(pub, v)
+			}
+		}
+		if len(pub) > 0 {
+			add(terminate(strings.Join(pub, ", ")))
+		}
+		return strings.Join(parts, " "), nil
+
+	case StyleChicago:
+		// Author, Author, and Author. Title [Translated title]. Edition. Place: Publisher, Year.
+		// 最初の著者名のみ"姓, 名"とする
+		authors := joinAuthors(c.names(inverted, natural), ", ", ", and ")
+		add(terminate(authors), terminate(title))
+		if len(c.edition) > 0 {
+			add(terminate(c.edition))
+		}
+		pub := c.publisher
+		if len(c.place) > 0 && len(pub) > 0 {
+			pub = c.place + ": " + pub
+		}
+		if len(c.year) > 0 {
+			if len(pub) > 0 {
+				pub += ", "
+			}
+			pub += c.year
+		}
+		if len(pub) > 0 {
+			add(terminate(pub))
+		}
+		return strings.Join(parts, " "), nil
+	}
+	return "", fmt.Errorf("cinii: unknown citation style: %d", style)
+}
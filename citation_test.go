@@ -0,0 +1,87 @@
+package cinii
+
+import (
+	"fmt"
+	"testing"
+)
+
+// citationRecord は著者、読み、英語のタイトル、シリーズ、ISBNを持つ和書のレコードを返す関数
+func citationRecord(t *testing.T) *Record {
+	t.Helper()
+	const src = `@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix dcterms: <http://purl.org/dc/terms/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix prism: <http://prismstandard.org/namespaces/basic/2.0/> .
+@prefix bibo: <http://purl.org/ontology/bibo/> .
+<https://ci.nii.ac.jp/ncid/BA12345678> dc:title "吾輩は猫である", "ワガハイ ワ ネコ デアル"@ja-Kana, "I am a cat"@en ;
+    dc:publisher "東京 : 岩波書店" ; dc:date "1990.4" ; prism:edition "改版" ; dcterms:extent "324p ; 19cm" ;
+    dcterms:isPartOf <https://ci.nii.ac.jp/ncid/BN00000001> ;
+    dcterms:hasPart <urn:isbn:9784003101018> ;
+    foaf:maker <https://ci.nii.ac.jp/author/DA00000001> .
+<https://ci.nii.ac.jp/ncid/BN00000001> dc:title "岩波文庫 ; 緑10-1" .
+<https://ci.nii.ac.jp/author/DA00000001> foaf:name "夏目, 漱石", "ナツメ, ソウセキ"@ja-Kana .
+<https://ci.nii.ac.jp/ncid/BA12345678#holdings> bibo:owner <https://ci.nii.ac.jp/library/FA000001> .`
+	r, err := ParseTurtle([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// latinRecord はn人の欧文の著者を持つ洋書のレコードを返す関数
+func latinRecord(t *testing.T, n int) *Record {
+	t.Helper()
+	names := []string{"Smith, John Ronald", "Doe, Jane", "Roe, Richard"}
+	src := `@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix bibo: <http://purl.org/ontology/bibo/> .
+<https://ci.nii.ac.jp/ncid/BB00000001> dc:title "Library catalogs" ; dc:publisher "Chicago : ALA" ; dc:date "2001" .
+<https://ci.nii.ac.jp/ncid/BB00000001#holdings> bibo:owner <https://ci.nii.ac.jp/library/FA000001> .
+`
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("<https://ci.nii.ac.jp/author/DA%08d>", 100+i)
+		src += fmt.Sprintf("<https://ci.nii.ac.jp/ncid/BB00000001> foaf:maker %s .\n%s foaf:name %q .\n", id, id, names[i])
+	}
+	r, err := ParseTurtle([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestFormatCitation(t *testing.T) {
+	ja := citationRecord(t)
+	tests := []struct {
+		name  string
+		r     *Record
+		style CitationStyle
+		want  string
+	}{
+		// SIST02は読みと英語のタイトルを用いず、和名は区切りを除く
+		{"SIST02", ja, StyleSIST02, "夏目漱石. 吾輩は猫である. 改版, 岩波書店, 1990, 324p, (岩波文庫, 緑10-1), ISBN 9784003101018."},
+		{"APA", ja, StyleAPA, "夏目, 漱石. (1990). 吾輩は猫である [I am a cat] (改版). 岩波書店."},
+		{"MLA", ja, StyleMLA, "夏目, 漱石. 吾輩は猫である [I am a cat]. 改版, 岩波書店, 1990."},
+		{"Chicago", ja, StyleChicago, "夏目, 漱石. 吾輩は猫である [I am a cat]. 改版. 東京: 岩波書店, 1990."},
+		{"APA 1 author", latinRecord(t, 1), StyleAPA, "Smith, J. R. (2001). Library catalogs. ALA."},
+		{"APA 2 authors", latinRecord(t, 2), StyleAPA, "Smith, J. R., & Doe, J. (2001). Library catalogs. ALA."},
+		{"APA 3 authors", latinRecord(t, 3), StyleAPA, "Smith, J. R., Doe, J., & Roe, R. (2001). Library catalogs. ALA."},
+		{"MLA 1 author", latinRecord(t, 1), StyleMLA, "Smith, John Ronald. Library catalogs. ALA, 2001."},
+		{"MLA 2 authors", latinRecord(t, 2), StyleMLA, "Smith, John Ronald, and Jane Doe. Library catalogs. ALA, 2001."},
+		{"MLA 3 authors", latinRecord(t, 3), StyleMLA, "Smith, John Ronald, et al. Library catalogs. ALA, 2001."},
+		{"Chicago 2 authors", latinRecord(t, 2), StyleChicago, "Smith, John Ronald, and Jane Doe. Library catalogs. Chicago: ALA, 2001."},
+		{"Chicago 3 authors", latinRecord(t, 3), StyleChicago, "Smith, John Ronald, Jane Doe, and Richard Roe. Library catalogs. Chicago: ALA, 2001."},
+		// SIST02は欧文名を"姓, 名"とし、著者名を"; "で区切る
+		{"SIST02 latin", latinRecord(t, 2), StyleSIST02, "Smith, John Ronald; Doe, Jane. Library catalogs. ALA, 2001."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatCitation(tt.r, tt.style)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatCitation(%s) = %q, want %q", tt.style, got, tt.want)
+			}
+		})
+	}
+}
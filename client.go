@@ -0,0 +1,279 @@
+package cinii
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultTransport はパッケージで共有する、接続を再利用するよう調整したhttp.Transport
+var DefaultTransport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   16,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// HTTPClient はパッケージレベルの関数が使用するhttp.Client
+var HTTPClient = &http.Client{
+	Transport: DefaultTransport,
+	Timeout:   60 * time.Second,
+}
+
+// maxDrainSize は接続を再利用するためにCloseで読み捨てる最大バイト数
+const maxDrainSize = 64 << 10
+
+// drainCloser はClose時に残りのデータを読み捨て、接続を再利用できるようにするio.ReadCloser
+type drainCloser struct {
+	io.ReadCloser
+}
+
+// Close はio.Closerインターフェースの実装
+func (d drainCloser) Close() error {
+	io.Copy(ioutil.Discard, io.LimitReader(d.ReadCloser, maxDrainSize))
+	return d.ReadCloser.Close()
+}
+
+// Client はコンテキストに対応したCiNii Books APIのクライアント構造体
+type Client struct {
+	HTTPClient  *http.Client // nilの場合はパッケージのHTTPClientを使う
+	AppID       string
+	Concurrency int           // 複数のレコードを並行して取得する際の最大数。0以下の場合はDefaultConcurrency
+	MinInterval time.Duration // リクエストを送信する最小の間隔。0以下の場合は制限しない
+	Prefetch    int           // 検索結果の自動のページ送りで並行して先読みするページ数。0以下の場合は先読みしない
+	MaxResults  int           // 検索結果の自動のページ送りで取得する最大の件数。0の場合はDefaultMaxResults、負の場合は制限しない
+
+	mu        sync.Mutex
+	libraries map[string]*Library // FAIDをキーとする所蔵館のキャッシュ
+	nextSlot  time.Time           // 次のリクエストを送信できる時刻
+}
+
+// DefaultConcurrency は複数のレコードを並行して取得する際の既定の最大数
+const DefaultConcurrency = 4
+
+// DefaultMaxResults は検索結果の自動のページ送りで取得する既定の最大の件数
+const DefaultMaxResults = 10000
+
+// NewClient はappidを指定してClientを返す関数
+func NewClient(appid string) *Client {
+	return &Client{AppID: appid}
+}
+
+// httpClient は使用するhttp.Clientを返すメソッド
+func (c *Client) httpClient() *http.Client {
+	if c == nil || c.HTTPClient == nil {
+		return HTTPClient
+	}
+	return c.HTTPClient
+}
+
+// appID はappidを返すメソッド。nilのClientでは空とする
+func (c *Client) appID() string {
+	if c == nil {
+		return ""
+	}
+	return c.AppID
+}
+
+// prefetch は検索結果の自動のページ送りで先読みするページ数を返すメソッド
+func (c *Client) prefetch() int {
+	if c == nil || c.Prefetch < 0 {
+		return 0
+	}
+	return c.Prefetch
+}
+
+// maxResults は検索結果の自動のページ送りで取得する最大の件数を返すメソッド。0は制限しないことを表す
+func (c *Client) maxResults() int {
+	switch {
+	case c == nil || c.MaxResults == 0:
+		return DefaultMaxResults
+	case c.MaxResults < 0:
+		return 0
+	}
+	return c.MaxResults
+}
+
+// concurrency は並行して取得する最大数を返すメソッド
+func (c *Client) concurrency() int {
+	if c == nil || c.Concurrency <= 0 {
+		return DefaultConcurrency
+	}
+	return c.Concurrency
+}
+
+// cachedLibrary はキャッシュした所蔵館を返すメソッド
+func (c *Client) cachedLibrary(faid string) (*Library, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.libraries[faid]
+	return l, ok
+}
+
+// cacheLibrary は所蔵館をキャッシュするメソッド
+func (c *Client) cacheLibrary(faid string, l *Library) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.libraries == nil {
+		c.libraries = make(map[string]*Library)
+	}
+	c.libraries[faid] = l
+}
+
+// wait はMinIntervalの間隔を保つよう、リクエストを送信できる時刻まで待つメソッド。
+// 並行して呼び出された場合は呼び出し順に送信時刻を割り当てる
+func (c *Client) wait(ctx context.Context) error {
+	if c == nil || c.MinInterval <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	now := time.Now()
+	slot := c.nextSlot
+	if slot.Before(now) {
+		slot = now
+	}
+	c.nextSlot = slot.Add(c.MinInterval)
+	c.mu.Unlock()
+
+	d := time.Until(slot)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// open はURLにappidを付加し、取得したデータをUTF-8で読み出すio.ReadCloserを返すメソッド
+func (c *Client) open(ctx context.Context, u string) (io.ReadCloser, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return openContext(ctx, c.httpClient(), u, c.appID())
+}
+
+// Get はNCIDまたは書誌のURLを受け取り、書誌の情報をRecord構造体のポインタで返すメソッド
+func (c *Client) Get(ctx context.Context, id string, opts ...ParseOption) (*Record, error) {
+	u, err := recordURL(id)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.open(ctx, u+".rdf")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return decodeRecord(body, newParseConfig(opts))
+}
+
+// Search はCiNii BooksをOpenSearchで検索するメソッド。qにappidがなければClientのAppIDを付加する。
+// formatの指定に応じてレスポンスを解析し、AtomFeedに変換して返す
+func (c *Client) Search(ctx context.Context, q url.Values) (*AtomFeed, error) {
+	body, err := c.openSearch(ctx, OpenSaerchEndpoint, q)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return decodeFeed(body, q.Get("format"))
+}
+
+// openSearch はOpenSearchのendpointにqを送信し、レスポンスを読み出すio.ReadCloserを返すメソッド。
+// qにappidがなければClientのAppIDを付加する
+func (c *Client) openSearch(ctx context.Context, endpoint string, q url.Values) (io.ReadCloser, error) {
+	if len(q.Get("appid")) == 0 && len(c.appID()) > 0 {
+		q = cloneValues(q)
+		q.Set("appid", c.appID())
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return openContext(ctx, c.httpClient(), endpoint+"?"+q.Encode(), "")
+}
+
+// cloneValues はurl.Valuesの複製を返す関数
+func cloneValues(q url.Values) url.Values {
+	ret := make(url.Values, len(q))
+	for k, v := range q {
+		ret[k] = append([]string(nil), v...)
+	}
+	return ret
+}
+
+// parallel はConcurrencyを上限にf(ctx, 0)からf(ctx, n-1)を並行して実行するメソッド。
+// いずれかが失敗した場合は残りの実行を中止して最初のエラーを返す
+func (c *Client) parallel(ctx context.Context, n int, f func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, n)
+	sem := make(chan struct{}, c.concurrency())
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			if errs[i] = f(ctx, i); errs[i] != nil {
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// 中止による二次的なエラーより先に起きたエラーを返す
+	var first error
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// fetchAll はNCIDの配列を受け取り、Concurrencyを上限に並行して取得したレコードをNCIDと同じ順序で返すメソッド
+func (c *Client) fetchAll(ctx context.Context, ids []string) ([]*Record, error) {
+	records := make([]*Record, len(ids))
+	err := c.parallel(ctx, len(ids), func(ctx context.Context, i int) (err error) {
+		records[i], err = c.Get(ctx, ids[i])
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,50 @@
+package cinii
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// testServerClient はhandlerで応答するhttptestのサーバを起動し、CiNiiへのリクエストを
+// そのサーバに送るClientを返す関数。サーバはテストの終了時に停止する
+func testServerClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := srv.Client().Transport
+	return &Client{HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return transport.RoundTrip(req)
+	})}}
+}
+
+func TestClientGetStatusError(t *testing.T) {
+	c := testServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("<html><body>Service Unavailable</body></html>"))
+	}))
+	c.AppID = "secret-appid"
+
+	_, err := c.Get(context.Background(), "BA12345678")
+	var se *StatusError
+	if !errors.As(err, &se) {
+		t.Fatalf("Get() error = %v, want *StatusError", err)
+	}
+	if se.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want %d", se.StatusCode, http.StatusServiceUnavailable)
+	}
+	if strings.Contains(err.Error(), "secret-appid") {
+		t.Errorf("error %q contains the appid", err)
+	}
+}
@@ -0,0 +1,133 @@
+package cinii
+
+import (
+	"sort"
+	"strings"
+)
+
+// ISBNCluster はISBNを共有する異なるNCIDの書誌のまとまりの構造体
+type ISBNCluster struct {
+	ISBNs   []ISBN   // 共有されるISBN（ISBN-13）
+	NCIDs   []string // まとまりに含まれるNCID
+	Indexes []int    // 入力の配列におけるインデックス
+}
+
+// Stringerインターフェースの実装。"9784000000000 9784000000001: BA00000000, BA00000001"の形式で返す
+func (c ISBNCluster) String() string {
+	isbns := make([]string, len(c.ISBNs))
+	for i, isbn := range c.ISBNs {
+		isbns[i] = string(isbn)
+	}
+	return strings.Join(isbns, " ") + ": " + strings.Join(c.NCIDs, ", ")
+}
+
+// clusterByISBN はn個の書誌をISBN-13が一致するものどうしでまとめ、2つ以上のNCIDを含むまとまりを返す関数。
+// まとまりは最初に現れた書誌の順とする
+func clusterByISBN(n int, ncidOf func(i int) string, isbnsOf func(i int) []ISBN) (ret []ISBNCluster) {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	owner := make(map[ISBN]int)
+	isbns := make([][]ISBN, n)
+	for i := 0; i < n; i++ {
+		for _, isbn := range isbnsOf(i) {
+			isbn = isbn.ISBN13()
+			isbns[i] = append(isbns[i], isbn)
+			if j, ok := owner[isbn]; ok {
+				a, b := find(i), find(j)
+				if a < b {
+					a, b = b, a
+				}
+				parent[a] = b
+			} else {
+				owner[isbn] = i
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i := 0; i < n; i++ {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], i)
+	}
+	sort.Ints(roots)
+
+	for _, root := range roots {
+		members := groups[root]
+		c := ISBNCluster{Indexes: members}
+		seenNCID := make(map[string]bool)
+		count := make(map[ISBN]int)
+		for _, i := range members {
+			if ncid := ncidOf(i); len(ncid) > 0 && !seenNCID[ncid] {
+				seenNCID[ncid] = true
+				c.NCIDs = append(c.NCIDs, ncid)
+			}
+			seenISBN := make(map[ISBN]bool)
+			for _, isbn := range isbns[i] {
+				if !seenISBN[isbn] {
+					seenISBN[isbn] = true
+					count[isbn]++
+				}
+			}
+		}
+		if len(c.NCIDs) < 2 {
+			continue
+		}
+		for isbn, k := range count {
+			if k > 1 {
+				c.ISBNs = append(c.ISBNs, isbn)
+			}
+		}
+		sort.Slice(c.ISBNs, func(i, j int) bool { return c.ISBNs[i] < c.ISBNs[j] })
+		ret = append(ret, c)
+	}
+	return
+}
+
+// ClusterRecordsByISBN はレコードの配列から、ISBNを共有する異なるNCIDのレコードのまとまりを返す関数。
+// nilとDescriptionのないレコードはNCIDもISBNもないものとして扱う
+func ClusterRecordsByISBN(records []*Record) []ISBNCluster {
+	empty := func(i int) bool {
+		return records[i] == nil || len(records[i].Descriptions) == 0
+	}
+	return clusterByISBN(len(records),
+		func(i int) string {
+			if empty(i) {
+				return ""
+			}
+			return records[i].Descriptions[0].NCID
+		},
+		func(i int) []ISBN {
+			if empty(i) {
+				return nil
+			}
+			isbns, _ := records[i].ISBNs()
+			return isbns
+		})
+}
+
+// ClusterEntriesByISBN は検索結果のエントリの配列から、ISBNを共有する異なるNCIDのエントリのまとまりを返す関数
+func ClusterEntriesByISBN(entries []Entry) []ISBNCluster {
+	return clusterByISBN(len(entries),
+		func(i int) string {
+			ncid, _ := entries[i].NCID()
+			return string(ncid)
+		},
+		func(i int) []ISBN {
+			isbns, _ := entries[i].ISBNs()
+			return isbns
+		})
+}
@@ -0,0 +1,70 @@
+package cinii
+
+import (
+	"reflect"
+	"testing"
+)
+
+// isbnRecord はNCIDと巻のISBNを持つレコードを返す関数
+func isbnRecord(ncid string, isbns ...string) *Record {
+	d := Description{NCID: ncid}
+	for _, isbn := range isbns {
+		d.HasPart = append(d.HasPart, ResourceField{ResourceAttr: ResourceAttr{"urn:isbn:" + isbn}})
+	}
+	return &Record{Descriptions: []Description{d}}
+}
+
+func TestClusterRecordsByISBN(t *testing.T) {
+	records := []*Record{
+		isbnRecord("BA00000001", "4101010013", "9784003101018"),
+		nil,
+		isbnRecord("BA00000002", "9784101010014"),
+		{},
+		isbnRecord("BA00000003", "9784003101018"),
+		isbnRecord("BA00000004", "9780804429573"),
+		isbnRecord("BA00000005", "080442957X"),
+		isbnRecord("BA00000004", "9780804429573"),
+		isbnRecord("BA00000006", "9791032701157"),
+		isbnRecord("BA00000006", "9791032701157"),
+		isbnRecord("BA00000007"),
+	}
+	want := []ISBNCluster{
+		{
+			ISBNs:   []ISBN{"9784003101018", "9784101010014"},
+			NCIDs:   []string{"BA00000001", "BA00000002", "BA00000003"},
+			Indexes: []int{0, 2, 4},
+		},
+		{
+			ISBNs:   []ISBN{"9780804429573"},
+			NCIDs:   []string{"BA00000004", "BA00000005"},
+			Indexes: []int{5, 6, 7},
+		},
+	}
+	got := ClusterRecordsByISBN(records)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ClusterRecordsByISBN() = %v, want %v", got, want)
+	}
+	if s, want := got[1].String(), "9780804429573: BA00000004, BA00000005"; s != want {
+		t.Errorf("String() = %q, want %q", s, want)
+	}
+
+	if got := ClusterRecordsByISBN([]*Record{nil, {}}); got != nil {
+		t.Errorf("ClusterRecordsByISBN(nil, empty) = %v, want nil", got)
+	}
+}
+
+func TestClusterEntriesByISBN(t *testing.T) {
+	entries := []Entry{
+		{ID: "https://ci.nii.ac.jp/ncid/BA00000001", HasPart: []string{"urn:isbn:4101010013"}},
+		{ID: "https://ci.nii.ac.jp/ncid/BA00000002", HasPart: []string{"urn:isbn:9784003101018"}},
+		{ID: "https://ci.nii.ac.jp/ncid/BA00000003", HasPart: []string{"urn:isbn:9784101010014"}},
+	}
+	want := []ISBNCluster{{
+		ISBNs:   []ISBN{"9784101010014"},
+		NCIDs:   []string{"BA00000001", "BA00000003"},
+		Indexes: []int{0, 2},
+	}}
+	if got := ClusterEntriesByISBN(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("ClusterEntriesByISBN() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,37 @@
+package cinii
+
+import "strings"
+
+// Work は内容著作注記の著作の構造体
+type Work struct {
+	Title  string
+	Author string
+}
+
+// ParseWork は"タイトル / 責任表示"形式の内容著作注記をタイトルと責任表示に分割する関数
+func ParseWork(s string) Work {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, " / "); i >= 0 {
+		return Work{
+			Title:  strings.TrimSpace(s[:i]),
+			Author: strings.TrimSpace(s[i+len(" / "):]),
+		}
+	}
+	return Work{Title: s}
+}
+
+// Works はレコードから内容著作注記をタイトルと責任表示に分割した配列を返すメソッド
+func (r *Record) Works() (ret []Work, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	fields := r.Descriptions[0].ContentOfWorks
+	if len(fields) == 0 {
+		return nil, false
+	}
+	ret = make([]Work, len(fields))
+	for i, field := range fields {
+		ret[i] = ParseWork(field)
+	}
+	return ret, true
+}
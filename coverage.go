@@ -0,0 +1,130 @@
+package cinii
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// CoverageRow は所蔵館ごとの所蔵状況の構造体
+type CoverageRow struct {
+	FAID  string
+	Name  string
+	Held  []bool // Coverage.NCIDsと同じ順序で、その書誌を所蔵する場合はtrue
+	Count int    // 所蔵する書誌の数
+}
+
+// Coverage は複数の書誌について所蔵館ごとの所蔵状況をまとめた構造体
+type Coverage struct {
+	NCIDs []string
+	Rows  []CoverageRow // 所蔵する書誌の多い順、所蔵館名、FAIDの順
+}
+
+// NewCoverage はレコードの配列から所蔵館ごとの所蔵状況を作る関数。
+// FAIDを持たない所蔵は所蔵館名で区別する
+func NewCoverage(records []*Record) *Coverage {
+	c := &Coverage{NCIDs: make([]string, len(records))}
+	index := make(map[string]int)
+	for i, r := range records {
+		if r == nil || len(r.Descriptions) == 0 {
+			continue
+		}
+		c.NCIDs[i] = r.Descriptions[0].NCID
+		holdings, ok := r.Holdings()
+		if !ok {
+			continue
+		}
+		for _, h := range holdings {
+			key := h[1]
+			if len(key) == 0 {
+				key = "\x00" + h[0]
+			}
+			j, ok := index[key]
+			if !ok {
+				j = len(c.Rows)
+				index[key] = j
+				c.Rows = append(c.Rows, CoverageRow{FAID: h[1], Name: h[0], Held: make([]bool, len(records))})
+			}
+			if !c.Rows[j].Held[i] {
+				c.Rows[j].Held[i] = true
+				c.Rows[j].Count++
+			}
+		}
+	}
+	sort.SliceStable(c.Rows, func(i, j int) bool {
+		a, b := c.Rows[i], c.Rows[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.FAID < b.FAID
+	})
+	return c
+}
+
+// HoldingAll はすべての書誌を所蔵する所蔵館を返すメソッド
+func (c *Coverage) HoldingAll() (ret []CoverageRow) {
+	for _, row := range c.Rows {
+		if row.Count == len(c.NCIDs) {
+			ret = append(ret, row)
+		}
+	}
+	return
+}
+
+// HoldingAny はいずれかの書誌を所蔵する所蔵館を返すメソッド
+func (c *Coverage) HoldingAny() []CoverageRow {
+	return c.Rows
+}
+
+// Missing は書誌ごとに、いずれかの書誌を所蔵する所蔵館のうちその書誌を所蔵しない所蔵館の数を返すメソッド
+func (c *Coverage) Missing() []int {
+	ret := make([]int, len(c.NCIDs))
+	for _, row := range c.Rows {
+		for i, held := range row.Held {
+			if !held {
+				ret[i]++
+			}
+		}
+	}
+	return ret
+}
+
+// WriteCSV は所蔵状況を所蔵館を行、書誌を列とする見出し行付きのCSVで書き出すメソッド。
+// 所蔵する場合は"1"、所蔵しない場合は空とする
+func (c *Coverage) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := append([]string{"faid", "name", "count"}, c.NCIDs...)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, row := range c.Rows {
+		line := make([]string, 0, len(header))
+		line = append(line, row.FAID, row.Name, strconv.Itoa(row.Count))
+		for _, held := range row.Held {
+			if held {
+				line = append(line, "1")
+			} else {
+				line = append(line, "")
+			}
+		}
+		if err := cw.Write(line); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Coverage はNCIDの配列を受け取り、書誌を並行して取得して所蔵館ごとの所蔵状況を返すメソッド
+func (c *Client) Coverage(ctx context.Context, ncids ...string) (*Coverage, error) {
+	records, err := c.fetchAll(ctx, ncids)
+	if err != nil {
+		return nil, err
+	}
+	return NewCoverage(records), nil
+}
@@ -0,0 +1,120 @@
+package cinii
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// CSLName はCSL-JSONの名前構造体
+type CSLName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+// CSLDate はCSL-JSONの日付構造体
+type CSLDate struct {
+	DateParts [][]int `json:"date-parts,omitempty"`
+	Literal   string  `json:"literal,omitempty"`
+}
+
+// CSLMulti はCSL-JSONの多言語拡張（citeproc-js）の構造体
+type CSLMulti struct {
+	Keys map[string]map[string]string `json:"_keys,omitempty"`
+}
+
+// CSLItem はCSL-JSONの項目構造体
+type CSLItem struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title,omitempty"`
+	Author         []CSLName `json:"author,omitempty"`
+	Publisher      string    `json:"publisher,omitempty"`
+	PublisherPlace string    `json:"publisher-place,omitempty"`
+	Issued         *CSLDate  `json:"issued,omitempty"`
+	Edition        string    `json:"edition,omitempty"`
+	Language       string    `json:"language,omitempty"`
+	ISBN           string    `json:"ISBN,omitempty"`
+	ISSN           string    `json:"ISSN,omitempty"`
+	URL            string    `json:"URL,omitempty"`
+	Note           string    `json:"note,omitempty"`
+	Multi          *CSLMulti `json:"multi,omitempty"`
+}
+
+// cslName は著者名をCSL-JSONの名前に変換する関数。姓名に分けられなければliteralとする
+func cslName(name string) CSLName {
+	n := ParseName(name)
+	if n.Corporate || len(n.Given) == 0 {
+		return CSLName{Literal: n.Family}
+	}
+	return CSLName{Family: n.Family, Given: n.Given}
+}
+
+// CSLItem はレコードをCSL-JSONの項目に変換するメソッド。
+// タイトルの読みや他言語のタイトルはmulti._keys.titleに格納する。Descriptionのないレコードはゼロ値を返す
+func (r *Record) CSLItem() CSLItem {
+	if len(r.Descriptions) == 0 {
+		return CSLItem{}
+	}
+	d := r.Descriptions[0]
+	item := CSLItem{ID: d.NCID, Type: "book"}
+	if r.Kind() == KindJournal {
+		item.Type = "periodical"
+	}
+
+	titles := pairReadings(d.Title)
+	multi := make(map[string]string)
+	for i, t := range titles {
+		if i == 0 {
+			item.Title = t.Title
+		} else if len(t.Lang) > 0 && len(t.Title) > 0 {
+			multi[t.Lang] = t.Title
+		}
+		if len(t.Reading) > 0 && i == 0 {
+			multi["ja-Kana"] = t.Reading
+		}
+	}
+	if len(multi) > 0 {
+		item.Multi = &CSLMulti{Keys: map[string]map[string]string{"title": multi}}
+	}
+
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			item.Author = append(item.Author, cslName(author[0]))
+		}
+	}
+	if publishers, ok := r.Publishers(); ok {
+		item.Publisher = publishers[0].Name
+		item.PublisherPlace = publishers[0].Place
+	}
+	if year, err := strconv.Atoi(firstYear(d.Date)); err == nil {
+		item.Issued = &CSLDate{DateParts: [][]int{{year}}}
+	} else if len(d.Date) > 0 {
+		item.Issued = &CSLDate{Literal: d.Date}
+	}
+	item.Edition = d.Edition
+	item.Language = d.Language
+	if isbns, ok := r.ISBNs(); ok {
+		item.ISBN = string(isbns[0])
+	}
+	if issns, ok := r.ISSNs(); ok {
+		item.ISSN = issns[0]
+	}
+	if len(d.NCID) > 0 {
+		item.URL = NCID(d.NCID).URL()
+		item.Note = "NCID: " + d.NCID
+	}
+	return item
+}
+
+// ToCSLJSON はレコードの配列をCSL-JSONに変換する関数。nilとDescriptionのないレコードは除く
+func ToCSLJSON(records ...*Record) ([]byte, error) {
+	items := make([]CSLItem, 0, len(records))
+	for _, record := range records {
+		if record == nil || len(record.Descriptions) == 0 {
+			continue
+		}
+		items = append(items, record.CSLItem())
+	}
+	return json.Marshal(items)
+}
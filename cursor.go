@@ -0,0 +1,94 @@
+package cinii
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Cursor は自動のページ送りの進捗を表す構造体。検索条件、次に取得する結果の番号、総件数を持つ。
+// Encodeで文字列に変換して保存し、中断したバッチ処理をDecodeCursorとResumeで続きから再開できる。
+// 文字列にはappidを含めないため、再開時はClientのAppIDまたはResumeの引数で改めて指定する
+type Cursor struct {
+	Params SearchParams // ページ送りに用いる検索条件
+	Next   int          // 次に取得する結果の番号（1から）
+	Total  int          // 総件数。不明の場合は-1
+}
+
+// cursorJSON はCursorの直列化の形式
+type cursorJSON struct {
+	Params SearchParams `json:"params"`
+	Next   int          `json:"next"`
+	Total  int          `json:"total"`
+}
+
+// Done はすべての検索結果を取得したかを返すメソッド
+func (c Cursor) Done() bool {
+	return c.Total >= 0 && c.Next > c.Total
+}
+
+// Resume は続きの検索結果を取得するSearchParamsを返すメソッド。appidが空でなければ検索条件のAppIDとする。
+// 取得する最初の結果の番号はページの途中の場合があるため、SearchAll等の自動のページ送りのメソッドに渡す
+func (c Cursor) Resume(appid string) SearchParams {
+	p := c.Params
+	p.Start = c.Next
+	if len(appid) > 0 {
+		p.AppID = appid
+	}
+	return p
+}
+
+// Encode はCursorをURLにも使える不透明な文字列に変換するメソッド。
+// 文字列は復号できるため、検索条件のAppIDは含めない
+func (c Cursor) Encode() string {
+	v := cursorJSON(c)
+	v.Params.AppID = ""
+	b, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Stringerインターフェースの実装。Encodeと同じ
+func (c Cursor) String() string {
+	return c.Encode()
+}
+
+// DecodeCursor はEncodeで変換した文字列からCursorを返す関数
+func DecodeCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("cinii: invalid cursor: %w", err)
+	}
+	var v cursorJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return Cursor{}, fmt.Errorf("cinii: invalid cursor: %w", err)
+	}
+	if v.Next < 1 {
+		return Cursor{}, fmt.Errorf("cinii: invalid cursor: next %d", v.Next)
+	}
+	return Cursor(v), nil
+}
+
+// MarshalText はencoding.TextMarshalerインターフェースの実装
+func (c Cursor) MarshalText() ([]byte, error) {
+	return []byte(c.Encode()), nil
+}
+
+// UnmarshalText はencoding.TextUnmarshalerインターフェースの実装
+func (c *Cursor) UnmarshalText(text []byte) error {
+	v, err := DecodeCursor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// SearchPages はSearchParamsの条件でOpenSearchの検索結果をページ順に取得し、ページのエントリと
+// そのページまでを取得した後のCursorでfを呼び出すメソッド。fでCursorを保存しておけば、
+// 中断した場合もCursor.Resumeの条件で続きから再開できる。fがエラーを返した場合はその時点で中止してエラーを返す
+func (c *Client) SearchPages(ctx context.Context, p SearchParams, f func(entries []Entry, cur Cursor) error) error {
+	return c.eachPage(ctx, p, func(feed *AtomFeed, cur Cursor) error {
+		return f(feed.Entries, cur)
+	})
+}
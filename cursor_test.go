@@ -0,0 +1,103 @@
+package cinii
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCursorEncode(t *testing.T) {
+	cur := Cursor{Params: SearchParams{Q: "夏目漱石", Count: 20, AppID: "secret-appid"}, Next: 41, Total: 100}
+	s := cur.Encode()
+	if strings.Contains(s, "secret-appid") {
+		t.Errorf("Encode() = %q contains the appid", s)
+	}
+	if b, err := base64.RawURLEncoding.DecodeString(s); err != nil || strings.Contains(string(b), "secret-appid") {
+		t.Errorf("decoded cursor %q contains the appid (err %v)", b, err)
+	}
+
+	got, err := DecodeCursor(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := cur
+	want.Params.AppID = ""
+	if got != want {
+		t.Errorf("DecodeCursor() = %+v, want %+v", got, want)
+	}
+
+	p := got.Resume("other-appid")
+	if p.Start != 41 || p.AppID != "other-appid" || p.Q != "夏目漱石" {
+		t.Errorf("Resume() = %+v", p)
+	}
+	if p := got.Resume(""); p.AppID != "" {
+		t.Errorf("Resume(\"\").AppID = %q, want empty", p.AppID)
+	}
+}
+
+func TestSearchPagesResume(t *testing.T) {
+	srv := &searchServer{total: 45}
+	c := testServerClient(t, srv)
+	c.AppID = "client-appid"
+	c.MaxResults = 25
+
+	// 25件で中断し、最後のCursorを文字列で保存する
+	var saved string
+	var got []Entry
+	err := c.SearchPages(context.Background(), SearchParams{Q: "猫", Count: 20, AppID: "param-appid"}, func(entries []Entry, cur Cursor) error {
+		got = append(got, entries...)
+		saved = cur.Encode()
+		return nil
+	})
+	if !errors.Is(err, ErrMaxResults) {
+		t.Fatalf("SearchPages() error = %v, want ErrMaxResults", err)
+	}
+	checkEntries(t, got, 1, 25)
+	for _, q := range srv.requests {
+		if got := q.Get("appid"); got != "param-appid" {
+			t.Errorf("appid = %q, want %q", got, "param-appid")
+		}
+	}
+	if b, _ := base64.RawURLEncoding.DecodeString(saved); strings.Contains(string(b), "param-appid") {
+		t.Errorf("saved cursor %q contains the appid", b)
+	}
+
+	cur, err := DecodeCursor(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cur.Next != 26 || cur.Total != 45 || cur.Done() {
+		t.Fatalf("cursor = %+v, want next 26 of 45", cur)
+	}
+
+	tests := []struct {
+		name  string
+		appid string
+		want  string
+	}{
+		{"client appid", "", "client-appid"},
+		{"resume appid", "resume-appid", "resume-appid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.requests = nil
+			c.MaxResults = 0
+			rest, err := c.SearchAll(context.Background(), cur.Resume(tt.appid))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkEntries(t, rest, 26, 20)
+			if got, want := srv.pages(), []int{2, 3}; !reflect.DeepEqual(got, want) {
+				t.Errorf("requested pages = %v, want %v", got, want)
+			}
+			for _, q := range srv.requests {
+				if got := q.Get("appid"); got != tt.want {
+					t.Errorf("appid = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
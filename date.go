@@ -0,0 +1,150 @@
+package cinii
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// uncertainYearPattern は一部の桁が不明な年のパターン。"19--", "199?", "[19--]"等
+	uncertainYearPattern = regexp.MustCompile(`^\[?([0-9]{2,3})([-?？]+)\]?$`)
+	// dottedDatePattern は"2001.4", "2001.4.15"のような年月日のパターン
+	dottedDatePattern = regexp.MustCompile(`([0-9]{4})\.([0-9]{1,2})(?:\.([0-9]{1,2}))?`)
+)
+
+// DateRange は出版年等の期間の構造体。Fromは期間の始まり、Toは期間の終わり（含まない）
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Stringerインターフェースの実装。"2001-04-01/2001-05-01"のように始まりと終わり（含まない）を返す
+func (d DateRange) String() string {
+	return d.From.Format("2006-01-02") + "/" + d.To.Format("2006-01-02")
+}
+
+// IsZero は期間が空かを返すメソッド
+func (d DateRange) IsZero() bool {
+	return d.From.IsZero() && d.To.IsZero()
+}
+
+// Years は期間を西暦年の範囲で返すメソッド
+func (d DateRange) Years() YearRange {
+	return YearRange{d.From.Year(), d.To.Add(-time.Nanosecond).Year()}
+}
+
+// Contains は時刻が期間に含まれるかを返すメソッド
+func (d DateRange) Contains(t time.Time) bool {
+	return !t.Before(d.From) && t.Before(d.To)
+}
+
+// Overlaps は2つの期間が重なるかを返すメソッド
+func (d DateRange) Overlaps(o DateRange) bool {
+	return d.From.Before(o.To) && o.From.Before(d.To)
+}
+
+// Before は期間の始まり、終わりの順で比較し、dがoより前かを返すメソッド
+func (d DateRange) Before(o DateRange) bool {
+	if !d.From.Equal(o.From) {
+		return d.From.Before(o.From)
+	}
+	return d.To.Before(o.To)
+}
+
+// yearStart は西暦年の1月1日を返す関数
+func yearStart(year int) time.Time {
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// dottedDate は"2001.4"形式の年月日から期間を返す関数
+func dottedDate(m []string) (DateRange, bool) {
+	year, _ := strconv.Atoi(m[1])
+	month, _ := strconv.Atoi(m[2])
+	if month < 1 || month > 12 {
+		return DateRange{}, false
+	}
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	if len(m[3]) == 0 {
+		return DateRange{from, from.AddDate(0, 1, 0)}, true
+	}
+	day, _ := strconv.Atoi(m[3])
+	if day < 1 || day > from.AddDate(0, 1, -1).Day() {
+		return DateRange{from, from.AddDate(0, 1, 0)}, true
+	}
+	from = from.AddDate(0, 0, day-1)
+	return DateRange{from, from.AddDate(0, 0, 1)}, true
+}
+
+// ParseDate は出版年等の文字列から期間を返す関数。
+// "2001.4"のような年月、"1998-2003"のような範囲、"19--"や"199?"のような一部の桁が不明な年、
+// ParseYearsが扱う和暦年を扱う
+func ParseDate(s string) (DateRange, bool) {
+	s = strings.TrimSpace(toHalfWidthDigits(s))
+	if m := uncertainYearPattern.FindStringSubmatch(s); m != nil && len(m[1])+len([]rune(m[2])) == 4 {
+		unit := 1
+		for i := len(m[1]); i < 4; i++ {
+			unit *= 10
+		}
+		from, _ := strconv.Atoi(m[1])
+		from *= unit
+		return DateRange{yearStart(from), yearStart(from + unit)}, true
+	}
+
+	years, ok := ParseYears(s)
+	if !ok {
+		return DateRange{}, false
+	}
+	d := DateRange{yearStart(years.From), yearStart(years.To + 1)}
+	matches := dottedDatePattern.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return d, true
+	}
+	if first, ok := dottedDate(matches[0]); ok && first.From.Year() == years.From {
+		d.From = first.From
+	}
+	if last, ok := dottedDate(matches[len(matches)-1]); ok && last.From.Year() == years.To {
+		d.To = last.To
+	}
+	return d, true
+}
+
+// PublicationDate はレコードの出版年（dc:date）を期間で返すメソッド
+func (r *Record) PublicationDate() (DateRange, bool) {
+	if len(r.Descriptions) == 0 {
+		return DateRange{}, false
+	}
+	return ParseDate(r.Descriptions[0].Date)
+}
+
+// SortByDate はレコードの配列を出版年の古い順に並べ替える関数。出版年のないレコードは最後とする
+func SortByDate(records []*Record) {
+	type dated struct {
+		d  DateRange
+		ok bool
+	}
+	dates := make(map[*Record]dated, len(records))
+	for _, r := range records {
+		d, ok := r.PublicationDate()
+		dates[r] = dated{d, ok}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := dates[records[i]], dates[records[j]]
+		if a.ok != b.ok {
+			return a.ok
+		}
+		return a.ok && a.d.Before(b.d)
+	})
+}
+
+// FilterByDate はレコードの配列から出版年が期間と重なるレコードを返す関数
+func FilterByDate(records []*Record, d DateRange) (ret []*Record) {
+	for _, r := range records {
+		if pd, ok := r.PublicationDate(); ok && pd.Overlaps(d) {
+			ret = append(ret, r)
+		}
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package cinii
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"2001", "2001-01-01/2002-01-01", true},
+		{"2001.4", "2001-04-01/2001-05-01", true},
+		{"2001.12", "2001-12-01/2002-01-01", true},
+		{"2001.4.15", "2001-04-15/2001-04-16", true},
+		{"2001.2.30", "2001-02-01/2001-03-01", true},
+		{"2001.13", "2001-01-01/2002-01-01", true},
+		{"1998-2003", "1998-01-01/2004-01-01", true},
+		{"2001.4-2002.3", "2001-04-01/2002-04-01", true},
+		{"19--", "1900-01-01/2000-01-01", true},
+		{"199?", "1990-01-01/2000-01-01", true},
+		{"[19--]", "1900-01-01/2000-01-01", true},
+		{"１９９８", "1998-01-01/1999-01-01", true},
+		{"昭和64年", "1989-01-01/1990-01-01", true},
+		{"平成元年", "1989-01-01/1990-01-01", true},
+		{"平成31-令和元", "2019-01-01/2020-01-01", true},
+		{"昭和52-55", "1977-01-01/1981-01-01", true},
+		{"", "", false},
+		{"1---", "", false},
+		{"n.d.", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseDate(tt.in)
+			if ok != tt.ok {
+				t.Fatalf("ParseDate(%q) ok = %v, want %v", tt.in, ok, tt.ok)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("ParseDate(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateRangeYears(t *testing.T) {
+	d, _ := ParseDate("2001.4-2002.3")
+	if got, want := d.Years(), (YearRange{2001, 2002}); got != want {
+		t.Errorf("Years() = %v, want %v", got, want)
+	}
+	if !d.Contains(time.Date(2002, time.March, 31, 23, 0, 0, 0, time.UTC)) {
+		t.Error("Contains(2002-03-31) = false")
+	}
+	if d.Contains(time.Date(2002, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Contains(2002-04-01) = true")
+	}
+}
+
+func TestSortAndFilterByDate(t *testing.T) {
+	record := func(date string) *Record {
+		return &Record{Descriptions: []Description{{Date: date}}}
+	}
+	none, y2001, y2001apr, y19xx, showa := record(""), record("2001"), record("2001.4"), record("19--"), record("昭和52")
+	records := []*Record{none, y2001apr, y2001, y19xx, showa}
+
+	SortByDate(records)
+	if want := []*Record{y19xx, showa, y2001, y2001apr, none}; !reflect.DeepEqual(records, want) {
+		t.Errorf("SortByDate() = %v, want %v", dates(records), dates(want))
+	}
+
+	filter, _ := ParseDate("1977")
+	if got, want := FilterByDate(records, filter), []*Record{y19xx, showa}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterByDate() = %v, want %v", dates(got), dates(want))
+	}
+}
+
+// dates はテストの失敗時に表示するためにレコードの出版年を返す関数
+func dates(records []*Record) (ret []string) {
+	for _, r := range records {
+		ret = append(ret, r.Descriptions[0].Date)
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package cinii
+
+import "strings"
+
+// dedupPublisherWords は照合時に出版者名から除く語
+var dedupPublisherWords = strings.NewReplacer("株式会社", "", "(株)", "", "有限会社", "", "(有)", "", "出版社", "", "出版", "")
+
+// dedupComponent は照合キーの要素を正規化する関数。
+// NormalizeJaで正規化し、ひらがなをカタカナに、英字を小文字にして空白と記号を除く
+func dedupComponent(s string) string {
+	return toKatakana(matchKey(s))
+}
+
+// DedupKey はタイトル、著者名、出版年、出版者名から重複検出のための照合キーを作る関数。
+// タイトルは責任表示を除いた本タイトル、副題、部編番号とし、著者名は生没年を除く。
+// 出版年は最初の西暦年とし、出版者名は出版地と"株式会社"等を除く
+func DedupKey(title, author, date, publisher string) string {
+	t := ParseTitleStatement(title)
+	t.Responsibility = ""
+	n := ParseName(author)
+	n.Dates = ""
+	p := dedupPublisherWords.Replace(NormalizeJa(ParsePublisher(publisher).Name))
+	return strings.Join([]string{
+		dedupComponent(t.String()),
+		dedupComponent(n.Family + n.Given),
+		firstYear(date),
+		dedupComponent(p),
+	}, "|")
+}
+
+// DedupKey はレコードのタイトル、最初の著者、出版年、最初の出版者から照合キーを作るメソッド。
+// 著者がない場合はdc:creatorを用いる。Descriptionのないレコードは空文字列を返す
+func (r *Record) DedupKey() string {
+	if len(r.Descriptions) == 0 {
+		return ""
+	}
+	d := r.Descriptions[0]
+	title, _ := r.TitleWithReading()
+	author := d.Creator
+	if authors, ok := r.Authors(); ok && len(authors) > 0 {
+		author = authors[0][0]
+	}
+	var publisher string
+	if len(d.Publisher) > 0 {
+		publisher = d.Publisher[0]
+	}
+	return DedupKey(title.Title, author, d.Date, publisher)
+}
+
+// DedupKey は検索結果のエントリのタイトル、最初の著者、出版年、出版者から照合キーを作るメソッド
+func (e *Entry) DedupKey() string {
+	var author string
+	if len(e.Authors) > 0 {
+		author = e.Authors[0].Name
+	}
+	return DedupKey(e.Title, author, e.PubDate, e.Publisher)
+}
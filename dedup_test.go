@@ -0,0 +1,49 @@
+package cinii
+
+import "testing"
+
+func TestDedupKey(t *testing.T) {
+	base := DedupKey("吾輩は猫である", "夏目, 漱石", "1990", "岩波書店")
+	if want := "吾輩ハ猫デアル|夏目漱石|1990|岩波書店"; base != want {
+		t.Errorf("DedupKey() = %q, want %q", base, want)
+	}
+
+	tests := []struct {
+		name                           string
+		title, author, date, publisher string
+		same                           bool
+	}{
+		{"responsibility and place", "吾輩は猫である / 夏目漱石著", "夏目, 漱石, 1867-1916", "1990.4", "東京 : 岩波書店", true},
+		{"spaces and kana", "吾輩ハ 猫デアル", "夏目 漱石", "[1990]", "岩波書店", true},
+		{"company words", "吾輩は猫である", "夏目, 漱石", "1990", "株式会社岩波書店", true},
+		{"different year", "吾輩は猫である", "夏目, 漱石", "1991", "岩波書店", false},
+		{"different title", "坊っちゃん", "夏目, 漱石", "1990", "岩波書店", false},
+		{"different publisher", "吾輩は猫である", "夏目, 漱石", "1990", "新潮社", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DedupKey(tt.title, tt.author, tt.date, tt.publisher)
+			if (got == base) != tt.same {
+				t.Errorf("DedupKey() = %q, base %q, want same %v", got, base, tt.same)
+			}
+		})
+	}
+
+	if a, b := DedupKey("ＤＡＴＡＢＡＳＥ入門", "", "昭和52", "（株）オーム社"), DedupKey("Database入門", "", "1977", "オーム社"); a != b {
+		t.Errorf("DedupKey() = %q and %q, want equal", a, b)
+	}
+}
+
+func TestRecordDedupKey(t *testing.T) {
+	want := DedupKey("吾輩は猫である", "夏目 漱石", "1990", "岩波書店")
+	if got := citationRecord(t).DedupKey(); got != want {
+		t.Errorf("Record.DedupKey() = %q, want %q", got, want)
+	}
+	e := &Entry{Title: "吾輩は猫である / 夏目漱石著", Authors: []EntryAuthor{{Name: "夏目, 漱石"}}, PubDate: "1990.4", Publisher: "岩波書店"}
+	if got := e.DedupKey(); got != want {
+		t.Errorf("Entry.DedupKey() = %q, want %q", got, want)
+	}
+	if got := (&Record{}).DedupKey(); got != "" {
+		t.Errorf("empty record DedupKey() = %q, want empty", got)
+	}
+}
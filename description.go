@@ -0,0 +1,168 @@
+package cinii
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// OpenSearchDescriptionURL は、CiNii Books図書・雑誌書誌検索のOpenSearch記述文書のURI
+const OpenSearchDescriptionURL = "http://ci.nii.ac.jp/books/opensearch/description.xml"
+
+// OpenSearchDescription はOpenSearch記述文書（OpenSearchDescription）の構造体
+type OpenSearchDescription struct {
+	XMLName         xml.Name      `xml:"http://a9.com/-/spec/opensearch/1.1/ OpenSearchDescription"`
+	ShortName       string        `xml:"http://a9.com/-/spec/opensearch/1.1/ ShortName"`
+	LongName        string        `xml:"http://a9.com/-/spec/opensearch/1.1/ LongName"`
+	Description     string        `xml:"http://a9.com/-/spec/opensearch/1.1/ Description"`
+	Tags            string        `xml:"http://a9.com/-/spec/opensearch/1.1/ Tags"`
+	Contact         string        `xml:"http://a9.com/-/spec/opensearch/1.1/ Contact"`
+	Developer       string        `xml:"http://a9.com/-/spec/opensearch/1.1/ Developer"`
+	Attribution     string        `xml:"http://a9.com/-/spec/opensearch/1.1/ Attribution"`
+	Languages       []string      `xml:"http://a9.com/-/spec/opensearch/1.1/ Language"`
+	InputEncodings  []string      `xml:"http://a9.com/-/spec/opensearch/1.1/ InputEncoding"`
+	OutputEncodings []string      `xml:"http://a9.com/-/spec/opensearch/1.1/ OutputEncoding"`
+	URLs            []URLTemplate `xml:"http://a9.com/-/spec/opensearch/1.1/ Url"`
+}
+
+// URLTemplate はOpenSearch記述文書のUrl要素の構造体。Templateの{searchTerms}等を値に置き換えて検索のURLとする
+type URLTemplate struct {
+	Template    string `xml:"template,attr"`
+	Type        string `xml:"type,attr"`
+	Rel         string `xml:"rel,attr"` // 省略された場合は"results"
+	IndexOffset int    `xml:"indexOffset,attr"`
+	PageOffset  int    `xml:"pageOffset,attr"`
+}
+
+// TemplateParameter はURLテンプレートのパラメタの構造体
+type TemplateParameter struct {
+	Key      string // クエリパラメタ名。パス中のパラメタの場合は空
+	Name     string // テンプレートのパラメタ名（"searchTerms"、"cinii:year_from"等）
+	Optional bool   // "{name?}"の形式で省略できるか
+}
+
+// templateParamPattern はURLテンプレートのパラメタ（{name}または{name?}）のパターン
+var templateParamPattern = regexp.MustCompile(`\{([^{}?]+)(\??)\}`)
+
+// Parameters はURLテンプレートのパラメタを出現順に返すメソッド
+func (t URLTemplate) Parameters() []TemplateParameter {
+	var ret []TemplateParameter
+	path, query := t.Template, ""
+	if i := strings.Index(path, "?"); i >= 0 {
+		path, query = path[:i], path[i+1:]
+	}
+	for _, m := range templateParamPattern.FindAllStringSubmatch(path, -1) {
+		ret = append(ret, TemplateParameter{Name: m[1], Optional: len(m[2]) > 0})
+	}
+	for _, pair := range strings.Split(query, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		for _, m := range templateParamPattern.FindAllStringSubmatch(value, -1) {
+			ret = append(ret, TemplateParameter{Key: key, Name: m[1], Optional: len(m[2]) > 0})
+		}
+	}
+	return ret
+}
+
+// Expand はURLテンプレートのパラメタをvaluesの値で置き換えたURLを返すメソッド。
+// 値はパス中ではurl.PathEscape、クエリパラメタではurl.QueryEscapeでエスケープする。
+// 値のない省略できるパラメタは空とし、クエリパラメタの場合はパラメタごと除く。値のない必須のパラメタはエラーとする
+func (t URLTemplate) Expand(values map[string]string) (string, error) {
+	var missing []string
+	replace := func(s string, escape func(string) string) string {
+		return templateParamPattern.ReplaceAllStringFunc(s, func(m string) string {
+			sub := templateParamPattern.FindStringSubmatch(m)
+			if v, ok := values[sub[1]]; ok {
+				return escape(v)
+			}
+			if len(sub[2]) == 0 {
+				missing = append(missing, sub[1])
+			}
+			return ""
+		})
+	}
+
+	path, query := t.Template, ""
+	if i := strings.Index(path, "?"); i >= 0 {
+		path, query = path[:i], path[i+1:]
+	}
+	path = replace(path, url.PathEscape)
+	var pairs []string
+	for _, pair := range strings.Split(query, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		if len(key) == 0 {
+			continue
+		}
+		if expanded := replace(value, url.QueryEscape); len(expanded) > 0 || !templateParamPattern.MatchString(value) {
+			pairs = append(pairs, key+"="+expanded)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("cinii: missing template parameters: %s", strings.Join(missing, ", "))
+	}
+	if len(pairs) == 0 {
+		return path, nil
+	}
+	return path + "?" + strings.Join(pairs, "&"), nil
+}
+
+// URL は応答の形式（"application/atom+xml"等）が一致する検索結果のURLテンプレートを返すメソッド
+func (d *OpenSearchDescription) URL(mediaType string) (URLTemplate, bool) {
+	for _, t := range d.URLs {
+		if (t.Rel == "" || t.Rel == "results") && strings.EqualFold(t.Type, mediaType) {
+			return t, true
+		}
+	}
+	return URLTemplate{}, false
+}
+
+// ParseOpenSearchDescription はOpenSearch記述文書を含むbyte[]を受け取り、OpenSearchDescription構造体のポインタで返す関数
+func ParseOpenSearchDescription(body []byte) (*OpenSearchDescription, error) {
+	return decodeOpenSearchDescription(bytes.NewReader(body))
+}
+
+// decodeOpenSearchDescription はOpenSearch記述文書を読み出すio.Readerを受け取り、OpenSearchDescription構造体のポインタで返す関数
+func decodeOpenSearchDescription(r io.Reader) (*OpenSearchDescription, error) {
+	d := &OpenSearchDescription{}
+	if err := newXMLDecoder(r).Decode(d); err != nil {
+		return nil, err
+	}
+	// indexOffsetとpageOffsetの既定値は1
+	for i := range d.URLs {
+		if d.URLs[i].IndexOffset == 0 {
+			d.URLs[i].IndexOffset = 1
+		}
+		if d.URLs[i].PageOffset == 0 {
+			d.URLs[i].PageOffset = 1
+		}
+	}
+	return d, nil
+}
+
+// GetOpenSearchDescription はOpenSearch記述文書のURLを受け取り、取得した情報をOpenSearchDescription構造体のポインタで返す関数。
+// URLが空の場合はOpenSearchDescriptionURLとする
+func GetOpenSearchDescription(u string) (*OpenSearchDescription, error) {
+	return (*Client)(nil).OpenSearchDescription(context.Background(), u)
+}
+
+// OpenSearchDescription はOpenSearch記述文書のURLを受け取り、取得した情報をOpenSearchDescription構造体のポインタで返すメソッド。
+// URLが空の場合はOpenSearchDescriptionURLとする
+func (c *Client) OpenSearchDescription(ctx context.Context, u string) (*OpenSearchDescription, error) {
+	if len(u) == 0 {
+		u = OpenSearchDescriptionURL
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	body, err := openContext(ctx, c.httpClient(), u, "")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return decodeOpenSearchDescription(body)
+}
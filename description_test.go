@@ -0,0 +1,40 @@
+package cinii
+
+import "testing"
+
+func TestURLTemplateExpand(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		values   map[string]string
+		want     string
+	}{
+		{
+			"query parameters",
+			"https://ci.nii.ac.jp/books/opensearch/search?q={searchTerms}&count={count?}&format=atom",
+			map[string]string{"searchTerms": "吾輩は 猫"},
+			"https://ci.nii.ac.jp/books/opensearch/search?q=%E5%90%BE%E8%BC%A9%E3%81%AF+%E7%8C%AB&format=atom",
+		},
+		{
+			"path parameter",
+			"https://ci.nii.ac.jp/books/search/{searchTerms}?p={startPage?}",
+			map[string]string{"searchTerms": "a b/c", "startPage": "2"},
+			"https://ci.nii.ac.jp/books/search/a%20b%2Fc?p=2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := URLTemplate{Template: tt.template}.Expand(tt.values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (URLTemplate{Template: "https://example.jp/?q={searchTerms}"}).Expand(nil); err == nil {
+		t.Error("Expand() succeeded without a required parameter")
+	}
+}
@@ -0,0 +1,193 @@
+package cinii
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldChange は値が変わった項目の構造体
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Stringerインターフェースの実装
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %q -> %q", c.Field, c.Old, c.New)
+}
+
+// SetChange は複数の値を持つ項目で追加、削除された値の構造体
+type SetChange struct {
+	Field   string
+	Added   []string
+	Removed []string
+}
+
+// Stringerインターフェースの実装。"holdings: +FA000001 -FA000002"の形式で返す
+func (c SetChange) String() string {
+	parts := []string{c.Field + ":"}
+	for _, v := range c.Added {
+		parts = append(parts, "+"+v)
+	}
+	for _, v := range c.Removed {
+		parts = append(parts, "-"+v)
+	}
+	return strings.Join(parts, " ")
+}
+
+// RecordDiff は同じ書誌の2つのレコードの差分の構造体
+type RecordDiff struct {
+	NCID    string
+	Changes []FieldChange
+	Sets    []SetChange
+}
+
+// IsEmpty は差分がないかを返すメソッド
+func (d RecordDiff) IsEmpty() bool {
+	return len(d.Changes) == 0 && len(d.Sets) == 0
+}
+
+// Set は項目名が一致する追加、削除された値を返すメソッド
+func (d RecordDiff) Set(field string) (SetChange, bool) {
+	for _, s := range d.Sets {
+		if s.Field == field {
+			return s, true
+		}
+	}
+	return SetChange{}, false
+}
+
+// Stringerインターフェースの実装。差分を1行に1項目ずつ返す
+func (d RecordDiff) String() string {
+	var lines []string
+	for _, c := range d.Changes {
+		lines = append(lines, c.String())
+	}
+	for _, s := range d.Sets {
+		lines = append(lines, s.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffFields はレコードの単一の値を持つ項目名と値。Descriptionのないレコードの値はすべて空とする
+func diffFields(r *Record) [][2]string {
+	var d Description
+	var ownerCount string
+	if len(r.Descriptions) > 0 {
+		d = r.Descriptions[0]
+		ownerCount = strconv.Itoa(d.OwnerCount)
+	}
+	title, _ := r.TitleWithReading()
+	return [][2]string{
+		{"ncid", d.NCID},
+		{"title", title.Title},
+		{"reading", title.Reading},
+		{"creator", strings.TrimSpace(d.Creator)},
+		{"publisher", strings.Join(d.Publisher, "; ")},
+		{"date", strings.TrimSpace(d.Date)},
+		{"edition", strings.TrimSpace(d.Edition)},
+		{"language", strings.TrimSpace(d.Language)},
+		{"extent", strings.TrimSpace(d.Extent)},
+		{"owner_count", ownerCount},
+	}
+}
+
+// diffSets はレコードの複数の値を持つ項目名と値
+func diffSets(r *Record) (names []string, values [][]string) {
+	add := func(name string, vs []string) {
+		names = append(names, name)
+		values = append(values, vs)
+	}
+	isbns, _ := r.ISBNs()
+	var isbnValues []string
+	for _, isbn := range isbns {
+		isbnValues = append(isbnValues, string(isbn.ISBN13()))
+	}
+	add("isbns", isbnValues)
+
+	var holdings []string
+	if hs, ok := r.Holdings(); ok {
+		for _, h := range hs {
+			if len(h[1]) > 0 {
+				holdings = append(holdings, h[1])
+			} else {
+				holdings = append(holdings, h[0])
+			}
+		}
+	}
+	add("holdings", holdings)
+
+	var authors []string
+	if as, ok := r.Authors(); ok {
+		for _, a := range as {
+			authors = append(authors, a[0])
+		}
+	}
+	add("authors", authors)
+
+	topics, _ := r.Topics()
+	add("topics", topics)
+	notes, _ := r.Notes()
+	add("notes", notes)
+	return
+}
+
+// diffStrings はoldとnewの値の集合の差を並べ替えて返す関数
+func diffStrings(old, new []string) (added, removed []string) {
+	in := func(vs []string) map[string]bool {
+		m := make(map[string]bool, len(vs))
+		for _, v := range vs {
+			m[v] = true
+		}
+		return m
+	}
+	inOld, inNew := in(old), in(new)
+	for v := range inNew {
+		if !inOld[v] {
+			added = append(added, v)
+		}
+	}
+	for v := range inOld {
+		if !inNew[v] {
+			removed = append(removed, v)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return
+}
+
+// DiffRecords は同じ書誌の古いレコードaと新しいレコードbを項目ごとに比較し、差分を返す関数。
+// ISBNはISBN-13で、所蔵はFAID（ない場合は所蔵館名）で比較する。
+// nilとDescriptionのないレコード（取得に失敗したスナップショット等）はすべての項目が空のレコードとして比較する
+func DiffRecords(a, b *Record) RecordDiff {
+	if a == nil {
+		a = &Record{}
+	}
+	if b == nil {
+		b = &Record{}
+	}
+	oldFields, newFields := diffFields(a), diffFields(b)
+	// NCIDは新しいレコード、なければ古いレコードのものとする
+	diff := RecordDiff{NCID: newFields[0][1]}
+	if len(diff.NCID) == 0 {
+		diff.NCID = oldFields[0][1]
+	}
+	for i, f := range oldFields {
+		if f[1] != newFields[i][1] {
+			diff.Changes = append(diff.Changes, FieldChange{f[0], f[1], newFields[i][1]})
+		}
+	}
+	names, oldSets := diffSets(a)
+	_, newSets := diffSets(b)
+	for i, name := range names {
+		added, removed := diffStrings(oldSets[i], newSets[i])
+		if len(added) > 0 || len(removed) > 0 {
+			diff.Sets = append(diff.Sets, SetChange{name, added, removed})
+		}
+	}
+	return diff
+}
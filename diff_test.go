@@ -0,0 +1,87 @@
+package cinii
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// snapshotRecord は出版年、巻のISBN、所蔵館のFAIDを指定したBA00000001のレコードを返す関数
+func snapshotRecord(t *testing.T, date string, isbns, faids []string) *Record {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString(`@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix dcterms: <http://purl.org/dc/terms/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix bibo: <http://purl.org/ontology/bibo/> .
+@prefix cinii: <http://ci.nii.ac.jp/ns/1.0/> .
+`)
+	fmt.Fprintf(&b, "<https://ci.nii.ac.jp/ncid/BA00000001> cinii:ncid \"BA00000001\" ; dc:title \"こころ\" ; dc:publisher \"新潮社\" ; dc:date %q", date)
+	for _, isbn := range isbns {
+		fmt.Fprintf(&b, " ;\n    dcterms:hasPart <urn:isbn:%s>", isbn)
+	}
+	b.WriteString(" .\n")
+	for _, faid := range faids {
+		fmt.Fprintf(&b, "<https://ci.nii.ac.jp/ncid/BA00000001#holdings> bibo:owner <https://ci.nii.ac.jp/library/%s> .\n", faid)
+		fmt.Fprintf(&b, "<https://ci.nii.ac.jp/library/%s> foaf:name \"%s館\" .\n", faid, faid)
+	}
+	r, err := ParseTurtle([]byte(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestDiffRecords(t *testing.T) {
+	old := snapshotRecord(t, "1990", []string{"4101010013"}, []string{"FA000001", "FA000002"})
+	updated := snapshotRecord(t, "1991", []string{"9784101010014", "9784003101018"}, []string{"FA000001", "FA000003"})
+
+	diff := DiffRecords(old, updated)
+	want := RecordDiff{
+		NCID:    "BA00000001",
+		Changes: []FieldChange{{"date", "1990", "1991"}},
+		Sets: []SetChange{
+			{Field: "isbns", Added: []string{"9784003101018"}},
+			{Field: "holdings", Added: []string{"FA000003"}, Removed: []string{"FA000002"}},
+		},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Fatalf("DiffRecords() = %#v, want %#v", diff, want)
+	}
+	if got, want := diff.String(), "date: \"1990\" -> \"1991\"\nisbns: +9784003101018\nholdings: +FA000003 -FA000002"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if _, ok := diff.Set("authors"); ok {
+		t.Error("Set(\"authors\") ok = true")
+	}
+
+	if d := DiffRecords(old, snapshotRecord(t, "1990", []string{"978-4-10-101001-4"}, []string{"FA000002", "FA000001"})); !d.IsEmpty() {
+		t.Errorf("diff of equivalent records = %v, want empty", d)
+	}
+}
+
+func TestDiffRecordsEmpty(t *testing.T) {
+	r := parseTestRecord(t, "testdata/BA12345678.rdf", Parse)
+
+	for _, old := range []*Record{nil, {}} {
+		diff := DiffRecords(old, r)
+		if diff.NCID != "BA12345678" {
+			t.Errorf("NCID = %q, want BA12345678", diff.NCID)
+		}
+		if c := diff.Changes[0]; c != (FieldChange{"ncid", "", "BA12345678"}) {
+			t.Errorf("Changes[0] = %v", c)
+		}
+		if s, ok := diff.Set("holdings"); !ok || !reflect.DeepEqual(s.Added, []string{"FA000001", "FA000002", "FA000003"}) {
+			t.Errorf("holdings = %v", s)
+		}
+	}
+
+	diff := DiffRecords(r, &Record{})
+	if s, ok := diff.Set("holdings"); !ok || len(s.Removed) != 3 {
+		t.Errorf("holdings = %v", s)
+	}
+	if !DiffRecords(&Record{}, nil).IsEmpty() {
+		t.Error("diff of two empty records is not empty")
+	}
+}
@@ -0,0 +1,125 @@
+package cinii
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DissertationEndpoint は、CiNii Dissertations博士論文検索のOpenSearchのURI
+const DissertationEndpoint = "http://ci.nii.ac.jp/d/opensearch/search"
+
+// DissertationFeed はCiNii DissertationsのOpenSearchのAtom1.0レスポンス構造体
+type DissertationFeed struct {
+	XMLName      xml.Name            `xml:"http://www.w3.org/2005/Atom feed"`
+	Title        string              `xml:"http://www.w3.org/2005/Atom title"`
+	Links        []Link              `xml:"http://www.w3.org/2005/Atom link"`
+	ID           string              `xml:"http://www.w3.org/2005/Atom id"`
+	Updated      customTime          `xml:"http://www.w3.org/2005/Atom updated"`
+	TotalResults int                 `xml:"http://a9.com/-/spec/opensearch/1.1/ totalResults"`
+	StartIndex   int                 `xml:"http://a9.com/-/spec/opensearch/1.1/ startIndex"`
+	ItemsPerPage int                 `xml:"http://a9.com/-/spec/opensearch/1.1/ itemsPerPage"`
+	Entries      []DissertationEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+// DissertationEntry はDissertationFeedのエントリ構造体。タイトル、著者等はEntryと共通
+type DissertationEntry struct {
+	Entry
+	DegreeName         string `xml:"http://ndl.go.jp/dcndl/terms/ degreeName"`         // 学位名（"博士(文学)"等）
+	DissertationNumber string `xml:"http://ndl.go.jp/dcndl/terms/ dissertationNumber"` // 報告番号（"甲第1234号"等）
+	Grantor            string `xml:"http://ndl.go.jp/dcndl/terms/ grantor"`            // 学位授与機関
+	DateGranted        string `xml:"http://ndl.go.jp/dcndl/terms/ dateGranted"`        // 学位授与年月日
+}
+
+// Institution は学位授与機関を返すメソッド。grantorがない場合は出版者とする
+func (e *DissertationEntry) Institution() (string, bool) {
+	name := firstNonEmpty(e.Grantor, e.Publisher)
+	return name, len(name) > 0
+}
+
+// Year は学位授与年を返すメソッド。学位授与年月日がない場合は出版年とする
+func (e *DissertationEntry) Year() (int, bool) {
+	years, ok := ParseYears(firstNonEmpty(e.DateGranted, e.PubDate))
+	if !ok {
+		return 0, false
+	}
+	return years.From, true
+}
+
+// DissertationParams はCiNii DissertationsのOpenSearchの検索条件の構造体。ゼロ値の項目は送信しない
+type DissertationParams struct {
+	Q          string // フリーワード（q）
+	Title      string // 論文名（title）
+	Author     string // 著者名（author）
+	Grantor    string // 学位授与機関（grantor）
+	DegreeName string // 学位名（degreename）
+	YearFrom   int    // 学位授与年の下限（year_from）
+	YearTo     int    // 学位授与年の上限（year_to）
+
+	Count     int       // 1ページの件数（count）
+	Start     int       // 取得する最初の結果の番号（start）
+	SortOrder SortOrder // 並び順（sortorder）
+
+	AppID string // appid。空の場合はClientのAppIDを使う
+}
+
+// Values はDissertationParamsをOpenSearchのクエリパラメタに変換するメソッド
+func (p DissertationParams) Values() url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			q.Set(key, value)
+		}
+	}
+	setInt := func(key string, value int) {
+		if value > 0 {
+			q.Set(key, strconv.Itoa(value))
+		}
+	}
+	set("q", p.Q)
+	set("title", p.Title)
+	set("author", p.Author)
+	set("grantor", p.Grantor)
+	set("degreename", p.DegreeName)
+	setInt("year_from", p.YearFrom)
+	setInt("year_to", p.YearTo)
+	setInt("count", p.Count)
+	setInt("start", p.Start)
+	setInt("sortorder", int(p.SortOrder))
+	set("appid", p.AppID)
+	return q
+}
+
+// DissertationSearch はCiNii DissertationsをOpenSearchで検索する関数
+func DissertationSearch(q url.Values) (*DissertationFeed, error) {
+	return (*Client)(nil).DissertationSearch(context.Background(), q)
+}
+
+// DissertationSearch はCiNii DissertationsをOpenSearchで検索するメソッド。qにappidがなければClientのAppIDを付加する
+func (c *Client) DissertationSearch(ctx context.Context, q url.Values) (*DissertationFeed, error) {
+	body, err := c.openSearch(ctx, DissertationEndpoint, q)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return decodeDissertationFeed(body)
+}
+
+// ParseDissertationFeed はCiNii DissertationsのAtomFeedを含むbyte[]を受け取りDissertationFeed構造体のポインタで返す関数
+func ParseDissertationFeed(body []byte) (*DissertationFeed, error) {
+	return decodeDissertationFeed(bytes.NewReader(body))
+}
+
+// decodeDissertationFeed はCiNii DissertationsのAtomFeedを読み出すio.Readerを受け取り、DissertationFeed構造体のポインタで返す関数
+func decodeDissertationFeed(r io.Reader) (*DissertationFeed, error) {
+	feed := &DissertationFeed{}
+	if err := newXMLDecoder(r).Decode(feed); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
@@ -0,0 +1,146 @@
+package cinii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// queryFields はクエリ文字列の項目名とQueryBuilderへの設定
+var queryFields = map[string]func(b *QueryBuilder, value string) error{
+	"q":         func(b *QueryBuilder, v string) error { b.Keyword(v); return nil },
+	"keyword":   func(b *QueryBuilder, v string) error { b.Keyword(v); return nil },
+	"title":     func(b *QueryBuilder, v string) error { b.Title(v); return nil },
+	"author":    func(b *QueryBuilder, v string) error { b.Author(v); return nil },
+	"authorid":  func(b *QueryBuilder, v string) error { b.AuthorID(v); return nil },
+	"publisher": func(b *QueryBuilder, v string) error { b.Publisher(v); return nil },
+	"subject":   func(b *QueryBuilder, v string) error { b.Subject(v); return nil },
+	"note":      func(b *QueryBuilder, v string) error { b.Note(v); return nil },
+	"isbn":      func(b *QueryBuilder, v string) error { b.ISBN(v); return nil },
+	"issn":      func(b *QueryBuilder, v string) error { b.ISSN(v); return nil },
+	"ncid":      func(b *QueryBuilder, v string) error { b.NCID(v); return nil },
+	"clas":      func(b *QueryBuilder, v string) error { b.Classification(v); return nil },
+	"gmd":       func(b *QueryBuilder, v string) error { b.GMD(v); return nil },
+	"lang":      func(b *QueryBuilder, v string) error { b.Lang(v); return nil },
+	"library":   func(b *QueryBuilder, v string) error { b.LibraryID(v); return nil },
+	"fano":      func(b *QueryBuilder, v string) error { b.LibraryID(v); return nil },
+	"area":      func(b *QueryBuilder, v string) error { b.Area(v); return nil },
+	"year": func(b *QueryBuilder, v string) error {
+		from, to, err := parseYearRange(v)
+		if err == nil {
+			b.YearRange(from, to)
+		}
+		return err
+	},
+	"type": func(b *QueryBuilder, v string) error {
+		switch strings.ToLower(v) {
+		case "book", "books", "図書":
+			b.Material(MaterialBook)
+		case "serial", "serials", "journal", "雑誌":
+			b.Material(MaterialSerial)
+		case "any", "all":
+			b.Material(MaterialAny)
+		default:
+			return fmt.Errorf("cinii: unknown material type: %s", v)
+		}
+		return nil
+	},
+	"sort": func(b *QueryBuilder, v string) error {
+		order, err := ParseSortOrder(v)
+		if err == nil {
+			b.Sort(order)
+		}
+		return err
+	},
+	"count": func(b *QueryBuilder, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("cinii: invalid count: %s", v)
+		}
+		b.Count(n)
+		return nil
+	},
+	"start": func(b *QueryBuilder, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("cinii: invalid start: %s", v)
+		}
+		b.Start(n)
+		return nil
+	},
+}
+
+// parseYearRange は"1990"、"1990..2000"、"1990.."、"..2000"形式の出版年の範囲を返す関数
+func parseYearRange(s string) (from, to int, err error) {
+	atoi := func(v string) (int, error) {
+		if len(v) == 0 {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("cinii: invalid year: %s", s)
+		}
+		return n, nil
+	}
+	if i := strings.Index(s, ".."); i >= 0 {
+		if from, err = atoi(s[:i]); err != nil {
+			return
+		}
+		to, err = atoi(s[i+2:])
+		return
+	}
+	from, err = atoi(s)
+	return from, from, err
+}
+
+// splitQuery はクエリ文字列を空白で区切る関数。二重引用符で囲まれた空白は区切りとしない
+func splitQuery(s string) (tokens []string) {
+	var b strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			quoted = !quoted
+			b.WriteRune('"')
+		case !quoted && (r == ' ' || r == '　' || r == '\t' || r == '\n'):
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+	return
+}
+
+// ParseQuery は`title:"利己的な遺伝子" author:ドーキンス year:1990..2000`のようなクエリ文字列を
+// SearchParamsに変換する関数。項目名のない語や語句はフリーワードとし、
+// 項目名はSearchParamsのクエリパラメタ名のほか"year"、"type"、"sort"、"library"を受け付ける
+func ParseQuery(s string) (SearchParams, error) {
+	b := NewQuery()
+	var freewords []string
+	for _, token := range splitQuery(s) {
+		i := strings.IndexAny(token, ":：")
+		if i > 0 {
+			set, ok := queryFields[strings.ToLower(token[:i])]
+			if ok {
+				_, size := utf8.DecodeRuneInString(token[i:])
+				value := strings.Trim(token[i+size:], `"`)
+				if err := set(b, value); err != nil {
+					return SearchParams{}, err
+				}
+				continue
+			}
+		}
+		freewords = append(freewords, token)
+	}
+	if len(freewords) > 0 {
+		b.params.Q = And(append([]string{b.params.Q}, freewords...)...)
+	}
+	return b.Build()
+}
@@ -0,0 +1,61 @@
+package cinii
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		in   string
+		want SearchParams
+	}{
+		{`title:"利己的な遺伝子" author:ドーキンス year:1990..2000`, SearchParams{Title: "利己的な遺伝子", Author: "ドーキンス", YearFrom: 1990, YearTo: 2000}},
+		{`猫 "吾輩は 猫" title：こころ`, SearchParams{Q: `猫 "吾輩は 猫"`, Title: "こころ"}},
+		{`“吾輩は猫”　夏目`, SearchParams{Q: `"吾輩は猫" 夏目`}},
+		{`q:猫 犬`, SearchParams{Q: "猫 犬"}},
+		{`Title:Go AUTHOR:Pike`, SearchParams{Title: "Go", Author: "Pike"}},
+		{`year:1995`, SearchParams{YearFrom: 1995, YearTo: 1995}},
+		{`year:1990..`, SearchParams{YearFrom: 1990}},
+		{`year:..2000`, SearchParams{YearTo: 2000}},
+		{`type:雑誌 issn:0028-0836`, SearchParams{Type: MaterialSerial, ISSN: "0028-0836"}},
+		{`type:book isbn:4101010013`, SearchParams{Type: MaterialBook, ISBN: "4101010013"}},
+		{`sort:year_desc count:50 start:51`, SearchParams{SortOrder: ByYearDesc, Count: 50, Start: 51}},
+		{`fano:FA000001 clas:913.6 lang:jpn`, SearchParams{LibraryID: "FA000001", Classification: "913.6", Lang: "jpn"}},
+		{`url:http://example.com`, SearchParams{Q: "url:http://example.com"}},
+		{``, SearchParams{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseQuery(tt.in)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	for _, in := range []string{
+		`year:abc`,
+		`year:1990..x`,
+		`year:2000..1990`,
+		`type:video`,
+		`sort:random`,
+		`count:x`,
+		`count:500`,
+		`start:x`,
+		`start:2 count:20`,
+		`isbn:4101010013 issn:0028-0836`,
+		`type:serial isbn:4101010013`,
+		`title:a title:b`,
+		`library:FA1`,
+	} {
+		if got, err := ParseQuery(in); err == nil {
+			t.Errorf("ParseQuery(%q) = %+v, want error", in, got)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package cinii
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// TestExportEmptyRecord はDescriptionのないレコードを変換してもpanicしないことを確認する
+func TestExportEmptyRecord(t *testing.T) {
+	empty := &Record{}
+	tests := []struct {
+		name   string
+		export func() string
+	}{
+		{"BibTeX", empty.ToBibTeX},
+		{"RIS", empty.ToRIS},
+		{"OAI-DC", empty.ToOAIDC},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.export(); got != "" {
+				t.Errorf("got %q, want empty", got)
+			}
+		})
+	}
+
+	if _, err := empty.ToSchemaOrg(); err == nil {
+		t.Error("ToSchemaOrg() succeeded for an empty record")
+	}
+	if _, err := FormatRecord(empty, template.Must(template.New("").Parse("{{.Title}}"))); err == nil {
+		t.Error("FormatRecord() succeeded for an empty record")
+	}
+}
+
+// TestExportSkipsEmptyRecords は複数のレコードの変換でnilとDescriptionのないレコードを除くことを確認する
+func TestExportSkipsEmptyRecords(t *testing.T) {
+	r := parseTestRecord(t, "testdata/BA12345678.rdf", Parse)
+	records := []*Record{nil, {}, r}
+
+	t.Run("CSL-JSON", func(t *testing.T) {
+		body, err := ToCSLJSON(records...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var items []CSLItem
+		if err := json.Unmarshal(body, &items); err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 || items[0].ID != "BA12345678" {
+			t.Errorf("ToCSLJSON() = %s", body)
+		}
+	})
+	t.Run("MARCXML", func(t *testing.T) {
+		body, err := ToMARCXML(records...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Count(string(body), "<record>"); got != 1 {
+			t.Errorf("ToMARCXML() has %d records, want 1:\n%s", got, body)
+		}
+	})
+
+	t.Run("MARC-in-JSON", func(t *testing.T) {
+		body, err := ToMARCJSON(records...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var marcs []json.RawMessage
+		if err := json.Unmarshal(body, &marcs); err != nil {
+			t.Fatal(err)
+		}
+		if len(marcs) != 1 {
+			t.Errorf("ToMARCJSON() = %s", body)
+		}
+	})
+	t.Run("MODS", func(t *testing.T) {
+		body, err := ToMODS(records...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Count(string(body), "<mods "); got != 1 {
+			t.Errorf("ToMODS() has %d records, want 1:\n%s", got, body)
+		}
+	})
+	t.Run("Zotero RDF", func(t *testing.T) {
+		body := ToZoteroRDF(records...)
+		if got := strings.Count(body, "<bib:Book "); got != 1 {
+			t.Errorf("ToZoteroRDF() has %d items, want 1:\n%s", got, body)
+		}
+	})
+	t.Run("xlsx", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteXLSX(&buf, records); err != nil {
+			t.Fatal(err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 見出し行と1件のレコード、見出し行と3件の所蔵
+		want := map[string]int{"xl/worksheets/sheet1.xml": 2, "xl/worksheets/sheet2.xml": 4}
+		for _, f := range zr.File {
+			n, ok := want[f.Name]
+			if !ok {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Count(string(body), "<row "); got != n {
+				t.Errorf("%s has %d rows, want %d", f.Name, got, n)
+			}
+		}
+	})
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteCSV(&buf, records, ColumnNCID); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), "ncid\nBA12345678\n"; got != want {
+			t.Errorf("WriteCSV() = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package cinii
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseJSONFeed はOpenSearchのJSON形式（format=json）のレスポンスを含むbyte[]を受け取り、
+// AtomFeed構造体のポインタで返す関数
+func ParseJSONFeed(body []byte) (*AtomFeed, error) {
+	return decodeJSONFeed(bytes.NewReader(body))
+}
+
+// decodeJSONFeed はOpenSearchのJSON形式のレスポンスを読み出すio.Readerを受け取り、AtomFeed構造体のポインタで返す関数
+func decodeJSONFeed(r io.Reader) (*AtomFeed, error) {
+	var doc map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	// 検索結果は@graphのchannelとする
+	channel := doc
+	for _, v := range flatten(doc["@graph"]) {
+		if obj, ok := v.(map[string]interface{}); ok {
+			channel = obj
+			break
+		}
+	}
+
+	feed := &AtomFeed{
+		Title:        jsonText(channel["title"]),
+		ID:           jsonText(channel["@id"]),
+		Updated:      parseCustomTime(jsonText(channel["dc:date"])),
+		TotalResults: jsonInt(channel["opensearch:totalResults"]),
+		StartIndex:   jsonInt(channel["opensearch:startIndex"]),
+		ItemsPerPage: jsonInt(channel["opensearch:itemsPerPage"]),
+	}
+	if link := jsonText(channel["link"]); len(link) > 0 {
+		feed.Links = append(feed.Links, Link{Rel: "alternate", Type: "text/html", Href: link})
+	}
+	for _, v := range flatten(channel["items"]) {
+		if item, ok := v.(map[string]interface{}); ok {
+			feed.Entries = append(feed.Entries, jsonEntry(item))
+		}
+	}
+	feed.collectWarnings()
+	return feed, nil
+}
+
+// jsonEntry はJSON形式の検索結果の1件をEntryに変換する関数
+func jsonEntry(item map[string]interface{}) Entry {
+	e := Entry{
+		Title:      jsonText(item["title"]),
+		ID:         jsonText(item["link"]),
+		Publisher:  strings.Join(jsonTexts(item["dc:publisher"]), "; "),
+		PubDate:    jsonText(item["prism:publicationDate"]),
+		HasPart:    jsonTexts(item["dcterms:hasPart"]),
+		OwnerCount: jsonInt(item["cinii:ownerCount"]),
+		ISSN:       jsonText(item["prism:issn"]),
+	}
+	if len(e.ID) == 0 {
+		e.ID = strings.TrimSuffix(jsonText(item["@id"]), "#entity")
+	}
+	if len(e.PubDate) == 0 {
+		e.PubDate = jsonText(item["dc:date"])
+	}
+	creators := jsonTexts(item["dc:creator"])
+	for _, name := range creators {
+		e.Authors = append(e.Authors, EntryAuthor{Name: name})
+	}
+	e.Creator = strings.Join(creators, "; ")
+	if len(e.ID) > 0 {
+		e.Links = append(e.Links, Link{Rel: "alternate", Type: "text/html", Href: e.ID})
+	}
+	for _, v := range flatten(item["dcterms:isPartOf"]) {
+		if part, ok := v.(map[string]interface{}); ok {
+			e.IsPartOf = append(e.IsPartOf, EntryPart{Title: jsonText(part["dc:title"]), Link: jsonText(part["@id"])})
+		}
+	}
+	return e
+}
+
+// jsonText はJSONの値を文字列で返す関数。配列の場合は最初の値、オブジェクトの場合は@valueまたは@idとする
+func jsonText(v interface{}) string {
+	if texts := jsonTexts(v); len(texts) > 0 {
+		return texts[0]
+	}
+	return ""
+}
+
+// jsonTexts はJSONの値を空でない文字列の配列で返す関数
+func jsonTexts(v interface{}) (ret []string) {
+	for _, item := range flatten(v) {
+		var s string
+		switch item := item.(type) {
+		case string:
+			s = item
+		case float64, bool:
+			s = fmt.Sprint(item)
+		case map[string]interface{}:
+			if value, ok := item["@value"]; ok {
+				s = fmt.Sprint(value)
+			} else {
+				s, _ = item["@id"].(string)
+			}
+		}
+		if s = strings.TrimSpace(s); len(s) > 0 {
+			ret = append(ret, s)
+		}
+	}
+	return
+}
+
+// jsonInt はJSONの数値または数字の文字列を整数で返す関数
+func jsonInt(v interface{}) int {
+	n, _ := strconv.Atoi(jsonText(v))
+	return n
+}
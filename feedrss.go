@@ -0,0 +1,134 @@
+package cinii
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// rssFeed はRSS 2.0形式（format=rss）のレスポンス構造体
+type rssFeed struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Title         string    `xml:"title"`
+		Links         []rssLink `xml:"link"`
+		LastBuildDate string    `xml:"lastBuildDate"`
+		PubDate       string    `xml:"pubDate"`
+		DCDate        string    `xml:"http://purl.org/dc/elements/1.1/ date"`
+		TotalResults  int       `xml:"http://a9.com/-/spec/opensearch/1.1/ totalResults"`
+		StartIndex    int       `xml:"http://a9.com/-/spec/opensearch/1.1/ startIndex"`
+		ItemsPerPage  int       `xml:"http://a9.com/-/spec/opensearch/1.1/ itemsPerPage"`
+		Items         []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// rssLink はRSSのlink要素とAtomのlink要素の構造体。名前空間で区別する
+type rssLink struct {
+	XMLName xml.Name
+	Rel     string `xml:"rel,attr"`
+	Type    string `xml:"type,attr"`
+	Href    string `xml:"href,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// rssItem はRSS 2.0のitem要素の構造体
+type rssItem struct {
+	Title           string      `xml:"title"`
+	Links           []rssLink   `xml:"link"`
+	GUID            string      `xml:"guid"`
+	Author          string      `xml:"author"`
+	Creators        []string    `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Publishers      []string    `xml:"http://purl.org/dc/elements/1.1/ publisher"`
+	PubDate         string      `xml:"pubDate"`
+	DCDate          string      `xml:"http://purl.org/dc/elements/1.1/ date"`
+	PublicationDate string      `xml:"http://prismstandard.org/namespaces/basic/2.0/ publicationDate"`
+	ISSN            string      `xml:"http://prismstandard.org/namespaces/basic/2.0/ issn"`
+	HasPart         []string    `xml:"http://purl.org/dc/terms/ hasPart"`
+	IsPartOf        []EntryPart `xml:"http://purl.org/dc/terms/ isPartOf"`
+	OwnerCount      int         `xml:"http://ci.nii.ac.jp/ns/1.0/ ownerCount"`
+}
+
+// ParseRSSFeed はOpenSearchのRSS 2.0形式（format=rss）のレスポンスを含むbyte[]を受け取り、
+// AtomFeed構造体のポインタで返す関数
+func ParseRSSFeed(body []byte) (*AtomFeed, error) {
+	return decodeRSSFeed(bytes.NewReader(body))
+}
+
+// decodeRSSFeed はOpenSearchのRSS 2.0形式のレスポンスを読み出すio.Readerを受け取り、AtomFeed構造体のポインタで返す関数
+func decodeRSSFeed(r io.Reader) (*AtomFeed, error) {
+	rss := &rssFeed{}
+	if err := newXMLDecoder(r).Decode(rss); err != nil {
+		return nil, err
+	}
+
+	ch := &rss.Channel
+	feed := &AtomFeed{
+		Title:        strings.TrimSpace(ch.Title),
+		Links:        rssLinks(ch.Links),
+		Updated:      parseCustomTime(firstNonEmpty(ch.LastBuildDate, ch.PubDate, ch.DCDate)),
+		TotalResults: ch.TotalResults,
+		StartIndex:   ch.StartIndex,
+		ItemsPerPage: ch.ItemsPerPage,
+	}
+	if link, ok := feed.link("alternate"); ok {
+		feed.ID = link
+	}
+	for i := range ch.Items {
+		feed.Entries = append(feed.Entries, ch.Items[i].entry())
+	}
+	feed.collectWarnings()
+	return feed, nil
+}
+
+// rssLinks はRSSのlink要素をalternateのLinkに、Atomのlink要素をそのままLinkに変換する関数
+func rssLinks(links []rssLink) (ret []Link) {
+	for _, l := range links {
+		if l.XMLName.Space == "" {
+			if href := strings.TrimSpace(l.Text); len(href) > 0 {
+				ret = append(ret, Link{Rel: "alternate", Type: "text/html", Href: href})
+			}
+			continue
+		}
+		ret = append(ret, Link{Rel: l.Rel, Type: l.Type, Href: l.Href})
+	}
+	return
+}
+
+// firstNonEmpty は空白を除いて空でない最初の文字列を返す関数
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			return v
+		}
+	}
+	return ""
+}
+
+// entry はitem要素をEntryに変換するメソッド
+func (item *rssItem) entry() Entry {
+	e := Entry{
+		Title:      strings.TrimSpace(item.Title),
+		Links:      rssLinks(item.Links),
+		Publisher:  strings.Join(item.Publishers, "; "),
+		PubDate:    firstNonEmpty(item.PublicationDate, item.DCDate),
+		HasPart:    item.HasPart,
+		IsPartOf:   item.IsPartOf,
+		OwnerCount: item.OwnerCount,
+		Updated:    parseCustomTime(item.PubDate),
+		ISSN:       strings.TrimSpace(item.ISSN),
+	}
+	e.ID = strings.TrimSpace(item.GUID)
+	if link, ok := e.HTMLURL(); ok && !strings.Contains(e.ID, "://") {
+		e.ID = link
+	}
+	creators := item.Creators
+	if len(creators) == 0 && len(strings.TrimSpace(item.Author)) > 0 {
+		creators = []string{strings.TrimSpace(item.Author)}
+	}
+	for _, name := range creators {
+		e.Authors = append(e.Authors, EntryAuthor{Name: strings.TrimSpace(name)})
+	}
+	e.Creator = strings.Join(creators, "; ")
+	return e
+}
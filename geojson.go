@@ -0,0 +1,147 @@
+package cinii
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// GeoPoint は所蔵館の位置の構造体
+type GeoPoint struct {
+	Latitude    float64
+	Longitude   float64
+	Approximate bool // 都道府県庁所在地等で代用した概略の位置の場合はtrue
+}
+
+// Geocoder は所蔵の位置を返す関数の型。位置が不明な場合はfalseを返す
+type Geocoder func(h EnrichedHolding) (GeoPoint, bool)
+
+// prefectureCapitals は都道府県庁所在地の緯度経度
+var prefectureCapitals = map[string][2]float64{
+	"北海道": {43.064, 141.347}, "青森県": {40.824, 140.740}, "岩手県": {39.704, 141.153},
+	"宮城県": {38.269, 140.872}, "秋田県": {39.719, 140.102}, "山形県": {38.240, 140.363},
+	"福島県": {37.750, 140.468}, "茨城県": {36.342, 140.447}, "栃木県": {36.566, 139.884},
+	"群馬県": {36.391, 139.061}, "埼玉県": {35.857, 139.649}, "千葉県": {35.605, 140.123},
+	"東京都": {35.690, 139.692}, "神奈川県": {35.448, 139.642}, "新潟県": {37.902, 139.023},
+	"富山県": {36.695, 137.211}, "石川県": {36.594, 136.626}, "福井県": {36.065, 136.222},
+	"山梨県": {35.664, 138.568}, "長野県": {36.651, 138.181}, "岐阜県": {35.391, 136.722},
+	"静岡県": {34.977, 138.383}, "愛知県": {35.180, 136.907}, "三重県": {34.730, 136.509},
+	"滋賀県": {35.004, 135.868}, "京都府": {35.021, 135.756}, "大阪府": {34.686, 135.520},
+	"兵庫県": {34.691, 135.183}, "奈良県": {34.685, 135.833}, "和歌山県": {34.226, 135.168},
+	"鳥取県": {35.504, 134.238}, "島根県": {35.472, 133.051}, "岡山県": {34.662, 133.935},
+	"広島県": {34.397, 132.459}, "山口県": {34.186, 131.471}, "徳島県": {34.066, 134.559},
+	"香川県": {34.340, 134.043}, "愛媛県": {33.842, 132.766}, "高知県": {33.560, 133.531},
+	"福岡県": {33.607, 130.418}, "佐賀県": {33.249, 130.299}, "長崎県": {32.745, 129.874},
+	"熊本県": {32.790, 130.741}, "大分県": {33.238, 131.613}, "宮崎県": {31.911, 131.424},
+	"鹿児島県": {31.560, 130.558}, "沖縄県": {26.212, 127.681},
+}
+
+// CoordinateTable はFAIDと位置の対応表から位置を返すGeocoderを作る関数
+func CoordinateTable(table map[string]GeoPoint) Geocoder {
+	return func(h EnrichedHolding) (GeoPoint, bool) {
+		p, ok := table[h.FAID]
+		return p, ok
+	}
+}
+
+// LibraryGeocoder は所蔵館のRDFに含まれる緯度経度を返すGeocoder
+func LibraryGeocoder(h EnrichedHolding) (GeoPoint, bool) {
+	if h.Library == nil || (h.Library.Latitude == 0 && h.Library.Longitude == 0) {
+		return GeoPoint{}, false
+	}
+	return GeoPoint{Latitude: h.Library.Latitude, Longitude: h.Library.Longitude}, true
+}
+
+// PrefectureGeocoder は所蔵館の都道府県庁所在地を概略の位置として返すGeocoder
+func PrefectureGeocoder(h EnrichedHolding) (GeoPoint, bool) {
+	c, ok := prefectureCapitals[h.Prefecture()]
+	if !ok {
+		return GeoPoint{}, false
+	}
+	return GeoPoint{Latitude: c[0], Longitude: c[1], Approximate: true}, true
+}
+
+// ChainGeocoders は複数のGeocoderを順に試し、最初に見つかった位置を返すGeocoderを作る関数
+func ChainGeocoders(geocoders ...Geocoder) Geocoder {
+	return func(h EnrichedHolding) (GeoPoint, bool) {
+		for _, g := range geocoders {
+			if g == nil {
+				continue
+			}
+			if p, ok := g(h); ok {
+				return p, true
+			}
+		}
+		return GeoPoint{}, false
+	}
+}
+
+// DefaultGeocoder は所蔵館のRDFの緯度経度、都道府県庁所在地の順に試すGeocoder
+var DefaultGeocoder = ChainGeocoders(LibraryGeocoder, PrefectureGeocoder)
+
+// geoJSONFeature はGeoJSONのFeatureの構造体
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id,omitempty"`
+	Geometry   geoJSONPoint      `json:"geometry"`
+	Properties geoJSONProperties `json:"properties"`
+}
+
+// geoJSONPoint はGeoJSONのPointの構造体。座標は経度、緯度の順
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// geoJSONProperties は所蔵館のFeatureの属性の構造体
+type geoJSONProperties struct {
+	Name        string `json:"name"`
+	FAID        string `json:"faid,omitempty"`
+	OPACURL     string `json:"opacUrl,omitempty"`
+	Prefecture  string `json:"prefecture,omitempty"`
+	Type        string `json:"institutionType"`
+	Approximate bool   `json:"approximate"`
+}
+
+// geoJSONCollection はGeoJSONのFeatureCollectionの構造体
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// HoldingsGeoJSON はEnrichHoldingsで取得した所蔵をGeoJSONのFeatureCollectionに変換する関数。
+// geocodeがnilの場合はDefaultGeocoderを使い、位置が不明な所蔵は含まない
+func HoldingsGeoJSON(holdings []EnrichedHolding, geocode Geocoder) ([]byte, error) {
+	if geocode == nil {
+		geocode = DefaultGeocoder
+	}
+	fc := geoJSONCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+	for _, h := range holdings {
+		p, ok := geocode(h)
+		if !ok {
+			continue
+		}
+		fc.Features = append(fc.Features, geoJSONFeature{
+			Type:     "Feature",
+			ID:       h.FAID,
+			Geometry: geoJSONPoint{"Point", [2]float64{p.Longitude, p.Latitude}},
+			Properties: geoJSONProperties{
+				Name:        h.Name,
+				FAID:        h.FAID,
+				OPACURL:     h.OPACURL,
+				Prefecture:  h.Prefecture(),
+				Type:        h.Type().String(),
+				Approximate: p.Approximate,
+			},
+		})
+	}
+	return json.Marshal(fc)
+}
+
+// HoldingsGeoJSON はレコードの所蔵館の情報を取得し、所蔵館の位置をGeoJSONのFeatureCollectionで返すメソッド
+func (r *Record) HoldingsGeoJSON(ctx context.Context, client *Client, geocode Geocoder) ([]byte, error) {
+	holdings, err := r.EnrichHoldings(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return HoldingsGeoJSON(holdings, geocode)
+}
@@ -0,0 +1,313 @@
+package cinii
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// Triple はRDFトリプル構造体
+type Triple struct {
+	Subject   string
+	Predicate string
+	Object    string
+	Lang      string // 目的語がリテラルの場合の言語タグ
+	Literal   bool   // 目的語がリテラルか否か
+}
+
+// Graph はトリプルの集合
+type Graph []Triple
+
+// Find は主語と述語が一致するトリプルを返すメソッド。空文字列はすべてに一致する
+func (g Graph) Find(subject, predicate string) (ret Graph) {
+	for _, t := range g {
+		if (len(subject) == 0 || t.Subject == subject) &&
+			(len(predicate) == 0 || t.Predicate == predicate) {
+			ret = append(ret, t)
+		}
+	}
+	return
+}
+
+// Objects は主語と述語が一致するトリプルの目的語の配列を返すメソッド
+func (g Graph) Objects(subject, predicate string) (ret []string) {
+	for _, t := range g.Find(subject, predicate) {
+		ret = append(ret, t.Object)
+	}
+	return
+}
+
+// Subjects はグラフに含まれる主語の配列を出現順に返すメソッド
+func (g Graph) Subjects() (ret []string) {
+	seen := make(map[string]bool)
+	for _, t := range g {
+		if !seen[t.Subject] {
+			seen[t.Subject] = true
+			ret = append(ret, t.Subject)
+		}
+	}
+	return
+}
+
+// Predicates はグラフに含まれる述語の配列を出現順に返すメソッド
+func (g Graph) Predicates() (ret []string) {
+	seen := make(map[string]bool)
+	for _, t := range g {
+		if !seen[t.Predicate] {
+			seen[t.Predicate] = true
+			ret = append(ret, t.Predicate)
+		}
+	}
+	return
+}
+
+// triples はノードの集合をトリプルの集合に変換するメソッド。述語の名前空間は正規の名前空間URIとする
+func (g *nodeGraph) triples() (ret Graph) {
+	visited := make(map[*node]bool)
+	var walk func(n *node)
+	walk = func(n *node) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		for _, pred := range n.order {
+			for _, v := range n.props[pred] {
+				if len(v.IRI) > 0 || v.Node != nil {
+					ret = append(ret, Triple{Subject: n.id, Predicate: canonicalIRI(pred), Object: v.IRI})
+				} else {
+					ret = append(ret, Triple{Subject: n.id, Predicate: canonicalIRI(pred), Object: v.Text, Lang: v.Lang, Literal: true})
+				}
+			}
+		}
+		for _, pred := range n.order {
+			for _, v := range n.props[pred] {
+				if v.Node != nil {
+					walk(v.Node)
+				}
+			}
+		}
+	}
+	for _, n := range g.nodes {
+		walk(n)
+	}
+	return
+}
+
+// parseGraph はRDF/XMLを含むbyte[]を受け取りトリプルの集合を返す関数
+func parseGraph(body []byte) (Graph, error) {
+	p := &rdfxmlParser{d: newXMLDecoder(bytes.NewReader(body)), graph: newNodeGraph()}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.graph.triples(), nil
+}
+
+// rdfxmlParser はRDF/XMLをnodeGraphに変換する構造体
+type rdfxmlParser struct {
+	d     *xml.Decoder
+	graph *nodeGraph
+	blank int
+}
+
+func (p *rdfxmlParser) newBlank() string {
+	p.blank++
+	return fmt.Sprintf("_:genid%d", p.blank)
+}
+
+func (p *rdfxmlParser) parse() error {
+	for {
+		tok, err := p.d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Space == nsRDF && start.Name.Local == "RDF" {
+			return p.nodeElements(langOf(start, ""))
+		}
+		// rdf:RDFを省略した単独のノード要素
+		_, err = p.nodeElement(start, "", true)
+		return err
+	}
+}
+
+// nodeElements はrdf:RDF直下のノード要素を読み込むメソッド
+func (p *rdfxmlParser) nodeElements(lang string) error {
+	for {
+		tok, err := p.d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if _, err := p.nodeElement(tok, lang, true); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// nodeElement はノード要素を読み込みノードを返すメソッド
+func (p *rdfxmlParser) nodeElement(start xml.StartElement, lang string, top bool) (*node, error) {
+	lang = langOf(start, lang)
+	id := p.subject(start)
+	var n *node
+	if top {
+		n = p.graph.get(id)
+	} else {
+		n = newNode(id)
+	}
+	if !(start.Name.Space == nsRDF && start.Name.Local == "Description") {
+		n.add(nsRDF+"type", nodeValue{IRI: start.Name.Space + start.Name.Local})
+	}
+	p.propertyAttrs(n, start, lang)
+	return n, p.propertyElements(n, lang)
+}
+
+// subject はノード要素の主語を返すメソッド
+func (p *rdfxmlParser) subject(start xml.StartElement) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Space != nsRDF {
+			continue
+		}
+		switch attr.Name.Local {
+		case "about":
+			return attr.Value
+		case "nodeID":
+			return "_:" + attr.Value
+		case "ID":
+			return "#" + attr.Value
+		}
+	}
+	return p.newBlank()
+}
+
+// propertyAttrs はプロパティ属性をノードに追加するメソッド
+func (p *rdfxmlParser) propertyAttrs(n *node, start xml.StartElement, lang string) {
+	for _, attr := range start.Attr {
+		switch attr.Name.Space {
+		case nsRDF, xmlNamespace, "xml", "xmlns", "":
+			continue
+		}
+		n.add(attr.Name.Space+attr.Name.Local, nodeValue{Text: attr.Value, Lang: lang})
+	}
+}
+
+// propertyElements はプロパティ要素を読み込みノードに追加するメソッド
+func (p *rdfxmlParser) propertyElements(n *node, lang string) error {
+	for {
+		tok, err := p.d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if err := p.propertyElement(n, tok, lang); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// propertyElement はプロパティ要素を1つ読み込みノードに追加するメソッド
+func (p *rdfxmlParser) propertyElement(n *node, start xml.StartElement, lang string) error {
+	lang = langOf(start, lang)
+	pred := start.Name.Space + start.Name.Local
+
+	var resource, parseType string
+	hasResource := false
+	for _, attr := range start.Attr {
+		if attr.Name.Space != nsRDF {
+			continue
+		}
+		switch attr.Name.Local {
+		case "resource":
+			resource, hasResource = attr.Value, true
+		case "nodeID":
+			resource, hasResource = "_:"+attr.Value, true
+		case "parseType":
+			parseType = attr.Value
+		}
+	}
+
+	switch {
+	case hasResource:
+		obj := newNode(resource)
+		p.propertyAttrs(obj, start, lang)
+		v := nodeValue{IRI: resource}
+		if len(obj.order) > 0 {
+			v.Node = obj
+		}
+		n.add(pred, v)
+		return p.d.Skip()
+	case parseType == "Resource":
+		obj := newNode(p.newBlank())
+		n.add(pred, nodeValue{IRI: obj.id, Node: obj})
+		return p.propertyElements(obj, lang)
+	case parseType == "Literal":
+		var inner struct {
+			XML string `xml:",innerxml"`
+		}
+		if err := p.d.DecodeElement(&inner, &start); err != nil {
+			return err
+		}
+		n.add(pred, nodeValue{Text: inner.XML})
+		return nil
+	}
+
+	// rdf:resourceのないプロパティ属性はブランクノードのプロパティとする
+	obj := newNode("")
+	p.propertyAttrs(obj, start, lang)
+	if len(obj.order) > 0 {
+		obj.id = p.newBlank()
+		n.add(pred, nodeValue{IRI: obj.id, Node: obj})
+		return p.d.Skip()
+	}
+
+	// 値はリテラルまたは入れ子のノード要素
+	var text strings.Builder
+	for {
+		tok, err := p.d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			text.Write(tok)
+		case xml.StartElement:
+			obj, err := p.nodeElement(tok, lang, false)
+			if err != nil {
+				return err
+			}
+			n.add(pred, nodeValue{IRI: obj.id, Node: obj})
+			// 終了タグまで読み飛ばす
+			return p.d.Skip()
+		case xml.EndElement:
+			n.add(pred, nodeValue{Text: text.String(), Lang: lang})
+			return nil
+		}
+	}
+}
+
+// langOf は要素のxml:lang属性を返す関数。なければ継承した値を返す
+func langOf(start xml.StartElement, inherited string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "lang" && (attr.Name.Space == xmlNamespace || attr.Name.Space == "xml") {
+			return attr.Value
+		}
+	}
+	return inherited
+}
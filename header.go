@@ -0,0 +1,71 @@
+package cinii
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// headerFilter は最初のDescriptionのトークンのみを返すxml.TokenReader。
+// 所蔵館(bibo:owner)に達した時点で主要な要素が揃っていれば以降の読み込みを打ち切る
+type headerFilter struct {
+	d       *xml.Decoder
+	depth   int
+	seen    map[string]bool
+	done    bool
+	pending []xml.Token
+}
+
+// complete はタイトル、NCID、出版者がすでに読み込まれたか判定するメソッド
+func (f *headerFilter) complete() bool {
+	return f.seen[nsDC+"title"] && f.seen[nsCiNii+"ncid"] && f.seen[nsDC+"publisher"]
+}
+
+// Token はxml.TokenReaderインターフェースの実装
+func (f *headerFilter) Token() (xml.Token, error) {
+	for {
+		if len(f.pending) > 0 {
+			tok := f.pending[0]
+			f.pending = f.pending[1:]
+			return tok, nil
+		}
+		if f.done {
+			return nil, io.EOF
+		}
+
+		tok, err := f.d.Token()
+		if err != nil {
+			return tok, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			f.depth++
+			// 深さ3は最初のDescriptionの子要素
+			if f.depth == 3 {
+				name := t.Name.Space + t.Name.Local
+				if name == nsBIBO+"owner" {
+					if f.complete() {
+						f.done = true
+						f.pending = []xml.Token{
+							xml.EndElement{Name: xml.Name{Space: nsRDF, Local: "Description"}},
+							xml.EndElement{Name: xml.Name{Space: nsRDF, Local: "RDF"}},
+						}
+						continue
+					}
+					f.depth--
+					if err := f.d.Skip(); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				f.seen[name] = true
+			}
+		case xml.EndElement:
+			f.depth--
+			if f.depth == 1 {
+				f.done = true
+				f.pending = []xml.Token{xml.EndElement{Name: xml.Name{Space: nsRDF, Local: "RDF"}}}
+			}
+		}
+		return tok, nil
+	}
+}
@@ -0,0 +1,159 @@
+package cinii
+
+import (
+	"sort"
+	"strings"
+)
+
+// InstitutionType は所蔵館の機関種別
+type InstitutionType int
+
+// 機関種別の定数
+const (
+	InstitutionUnknown          InstitutionType = iota
+	InstitutionNational                         // 国立国会図書館等の国の機関
+	InstitutionUniversity                       // 大学
+	InstitutionJuniorCollege                    // 短期大学
+	InstitutionTechnicalCollege                 // 高等専門学校
+	InstitutionResearch                         // 研究所等
+	InstitutionPublic                           // 公共図書館
+)
+
+var institutionTypeNames = []string{"Unknown", "National", "University", "JuniorCollege", "TechnicalCollege", "Research", "Public"}
+
+// Stringerインターフェースの実装
+func (t InstitutionType) String() string {
+	if t >= 0 && int(t) < len(institutionTypeNames) {
+		return institutionTypeNames[t]
+	}
+	return institutionTypeNames[0]
+}
+
+// InstitutionTypeOf は所蔵館名から機関種別を判定する関数
+func InstitutionTypeOf(name string) InstitutionType {
+	switch {
+	case strings.Contains(name, "国立国会図書館"):
+		return InstitutionNational
+	case strings.Contains(name, "短期大学"):
+		return InstitutionJuniorCollege
+	case strings.Contains(name, "高等専門学校") || strings.Contains(name, "高専"):
+		return InstitutionTechnicalCollege
+	case strings.Contains(name, "大学") || strings.Contains(name, "University"):
+		return InstitutionUniversity
+	case strings.Contains(name, "研究所") || strings.Contains(name, "研究機構") || strings.Contains(name, "研究センター"):
+		return InstitutionResearch
+	case strings.HasSuffix(name, "図書館") || strings.Contains(name, "県立") || strings.Contains(name, "市立") || strings.Contains(name, "区立"):
+		return InstitutionPublic
+	}
+	return InstitutionUnknown
+}
+
+// Type は所蔵館の機関種別を返すメソッド
+func (h EnrichedHolding) Type() InstitutionType {
+	return InstitutionTypeOf(h.Name)
+}
+
+// Prefecture は所蔵館の都道府県を返すメソッド。所蔵館の情報がない場合は空
+func (h EnrichedHolding) Prefecture() string {
+	if h.Library == nil {
+		return ""
+	}
+	return h.Library.Prefecture
+}
+
+// Region は所蔵館の地方（北海道、東北、関東、中部、近畿、中国、四国、九州）を返すメソッド。不明な場合は空
+func (h EnrichedHolding) Region() string {
+	return RegionOf(h.Prefecture())
+}
+
+// regionOrder は地方の並び順
+var regionOrder = []string{"北海道", "東北", "関東", "中部", "近畿", "中国", "四国", "九州"}
+
+// RegionOf は都道府県名から地方名を返す関数。不明な場合は空
+func RegionOf(prefecture string) string {
+	for _, region := range regionOrder {
+		for _, pref := range regions[region] {
+			if pref == prefecture {
+				return region
+			}
+		}
+	}
+	return ""
+}
+
+// HoldingsList はレコードから所蔵館名、FAID、所蔵館OPACにおけるこの書誌のURLを持つ所蔵の配列を
+// 所蔵館名、FAIDの順に並べ替えて返すメソッド。所蔵館の情報（Library）は持たない
+func (r *Record) HoldingsList() (ret []EnrichedHolding, ok bool) {
+	holdings, ok := r.Holdings()
+	if !ok {
+		return nil, false
+	}
+	ret = make([]EnrichedHolding, len(holdings))
+	for i, h := range holdings {
+		ret[i] = EnrichedHolding{Name: h[0], FAID: h[1], OPACURL: h[2]}
+	}
+	sortHoldings(ret)
+	return ret, true
+}
+
+// sortHoldings は所蔵の配列を所蔵館名、FAIDの順に並べ替える関数
+func sortHoldings(holdings []EnrichedHolding) {
+	sort.SliceStable(holdings, func(i, j int) bool {
+		if holdings[i].Name != holdings[j].Name {
+			return holdings[i].Name < holdings[j].Name
+		}
+		return holdings[i].FAID < holdings[j].FAID
+	})
+}
+
+// HoldingGroupKey は所蔵をまとめる基準
+type HoldingGroupKey int
+
+// 所蔵をまとめる基準の定数
+const (
+	GroupByType       HoldingGroupKey = iota // 機関種別
+	GroupByRegion                            // 地方
+	GroupByPrefecture                        // 都道府県
+)
+
+// HoldingGroup はまとめた所蔵の構造体
+type HoldingGroup struct {
+	Key      string // 機関種別、地方、都道府県の名前。不明な場合は空
+	Holdings []EnrichedHolding
+}
+
+// GroupHoldings は所蔵を基準ごとにまとめて返す関数。
+// グループは機関種別の定数順、地方は北から、都道府県は全国地方公共団体コード順に並べ、不明なグループは最後とする。
+// グループ内の所蔵は所蔵館名、FAIDの順に並べる
+func GroupHoldings(holdings []EnrichedHolding, key HoldingGroupKey) []HoldingGroup {
+	var keyOf func(h EnrichedHolding) string
+	var order []string
+	switch key {
+	case GroupByRegion:
+		keyOf, order = EnrichedHolding.Region, regionOrder
+	case GroupByPrefecture:
+		keyOf, order = EnrichedHolding.Prefecture, prefectures
+	default:
+		keyOf = func(h EnrichedHolding) string { return h.Type().String() }
+		order = institutionTypeNames[1:]
+	}
+
+	groups := make(map[string][]EnrichedHolding)
+	for _, h := range holdings {
+		k := keyOf(h)
+		if k == InstitutionUnknown.String() {
+			k = ""
+		}
+		groups[k] = append(groups[k], h)
+	}
+
+	var ret []HoldingGroup
+	for _, k := range append(order[:len(order):len(order)], "") {
+		if hs, ok := groups[k]; ok {
+			sorted := append([]EnrichedHolding(nil), hs...)
+			sortHoldings(sorted)
+			ret = append(ret, HoldingGroup{k, sorted})
+		}
+	}
+	return ret
+}
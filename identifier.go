@@ -0,0 +1,207 @@
+package cinii
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// IdentifierType は識別子の種別
+type IdentifierType int
+
+// 識別子種別の定数
+const (
+	IdentifierUnknown       IdentifierType = iota
+	IdentifierNCID                         // CiNii Books書誌ID (BN12345678)
+	IdentifierNAID                         // CiNii Articles論文ID
+	IdentifierFAID                         // CiNii Books所蔵館ID (FA000001)
+	IdentifierAuthorID                     // CiNii Books著者ID (DA00000001)
+	IdentifierCRID                         // CiNii Research ID
+	IdentifierISBN                         // ISBN-10/ISBN-13
+	IdentifierISSN                         // ISSN
+	IdentifierNBN                          // 全国書誌番号
+	IdentifierJPNO                         // JP番号
+	IdentifierNDLCallNumber                // 国立国会図書館請求記号
+	IdentifierOCLC                         // OCLC番号
+	IdentifierLCCN                         // 米国議会図書館管理番号
+)
+
+var identifierTypeNames = []string{
+	"Unknown", "NCID", "NAID", "FAID", "AuthorID", "CRID", "ISBN", "ISSN",
+	"NBN", "JPNO", "NDLCallNumber", "OCLC", "LCCN",
+}
+
+// Stringerインターフェースの実装
+func (t IdentifierType) String() string {
+	if int(t) < len(identifierTypeNames) {
+		return identifierTypeNames[t]
+	}
+	return identifierTypeNames[0]
+}
+
+// Identifier は種別付きの識別子構造体
+type Identifier struct {
+	Type  IdentifierType
+	Value string
+}
+
+// Stringerインターフェースの実装
+func (id Identifier) String() string {
+	return fmt.Sprintf("%s:%s", id.Type, id.Value)
+}
+
+// NCID はCiNii Books書誌ID
+type NCID string
+
+// NewNCID は文字列を検証し、大文字に正規化したNCIDを返す関数
+func NewNCID(s string) (NCID, error) {
+	id := strings.ToUpper(strings.TrimSpace(s))
+	if !ncidPattern.MatchString(id) {
+		return "", fmt.Errorf("cinii: invalid NCID: %q", s)
+	}
+	return NCID(id), nil
+}
+
+// Stringerインターフェースの実装
+func (n NCID) String() string {
+	return string(n)
+}
+
+// URL はNCIDに対応する書誌のURLを返すメソッド
+func (n NCID) URL() string {
+	return fmt.Sprintf("%s/%s", RetrieveEndopoint, n)
+}
+
+var (
+	ncidPattern     = regexp.MustCompile(`^[A-Z]{2}[0-9]{7}[0-9X]$`)
+	faidPattern     = regexp.MustCompile(`^FA[0-9]{6}$`)
+	authorIDPattern = regexp.MustCompile(`^DA[0-9]{7}[0-9X]$`)
+	naidPattern     = regexp.MustCompile(`^[0-9]{9,12}$`)
+	cridPattern     = regexp.MustCompile(`^[0-9]{19}$`)
+	issnPattern     = regexp.MustCompile(`^[0-9]{4}-?[0-9]{3}[0-9X]$`)
+	isbnPattern     = regexp.MustCompile(`^(?:[0-9]{9}[0-9X]|97[89][0-9]{10})$`)
+)
+
+// ParseIdentifier は文字列（CiNiiのURLを含む）を受け取り、種別を判定したIdentifierを返す関数。
+// 10桁の数字はISBN-10のチェックディジットが正しければISBN、そうでなければNAIDと判定する
+func ParseIdentifier(s string) (Identifier, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "://") {
+		t, id, err := ParseResourceURL(s)
+		if err != nil {
+			return Identifier{}, err
+		}
+		switch t {
+		case ResourceBook:
+			return Identifier{IdentifierNCID, strings.ToUpper(id)}, nil
+		case ResourceAuthor:
+			return Identifier{IdentifierAuthorID, strings.ToUpper(id)}, nil
+		case ResourceLibrary:
+			return Identifier{IdentifierFAID, strings.ToUpper(id)}, nil
+		case ResourceArticle:
+			return Identifier{IdentifierNAID, id}, nil
+		case ResourceResearch:
+			return Identifier{IdentifierCRID, id}, nil
+		}
+	}
+
+	lower := strings.ToLower(s)
+	for _, prefix := range []string{"urn:isbn:", "isbn:", "isbn"} {
+		if strings.HasPrefix(lower, prefix) {
+			if v := normalizeISBN(s[len(prefix):]); isbnPattern.MatchString(v) {
+				return Identifier{IdentifierISBN, v}, nil
+			}
+		}
+	}
+	for _, prefix := range []string{"urn:issn:", "issn:", "issn"} {
+		if strings.HasPrefix(lower, prefix) {
+			if v := strings.ToUpper(strings.TrimSpace(s[len(prefix):])); issnPattern.MatchString(v) {
+				return Identifier{IdentifierISSN, formatISSN(v)}, nil
+			}
+		}
+	}
+
+	upper := strings.ToUpper(s)
+	switch {
+	case authorIDPattern.MatchString(upper):
+		return Identifier{IdentifierAuthorID, upper}, nil
+	case faidPattern.MatchString(upper):
+		return Identifier{IdentifierFAID, upper}, nil
+	case ncidPattern.MatchString(upper):
+		return Identifier{IdentifierNCID, upper}, nil
+	case cridPattern.MatchString(s):
+		return Identifier{IdentifierCRID, s}, nil
+	case strings.Contains(s, "-") && issnPattern.MatchString(upper) && len(upper) == 9:
+		return Identifier{IdentifierISSN, upper}, nil
+	}
+
+	if v := normalizeISBN(s); isbnPattern.MatchString(v) && validISBN(v) {
+		return Identifier{IdentifierISBN, v}, nil
+	}
+	if naidPattern.MatchString(s) {
+		return Identifier{IdentifierNAID, s}, nil
+	}
+	return Identifier{}, fmt.Errorf("cinii: unknown identifier: %s", s)
+}
+
+// formatISSN はISSNを"1234-567X"の形式にする関数
+func formatISSN(issn string) string {
+	issn = strings.Replace(issn, "-", "", -1)
+	if len(issn) != 8 {
+		return issn
+	}
+	return issn[:4] + "-" + issn[4:]
+}
+
+// Identifiers はレコードのNCID、ISBN、ISSN、全国書誌番号、JP番号、NDL請求記号、OCLC番号、LCCNを
+// 種別付きの識別子の配列で返すメソッド
+func (r *Record) Identifiers() (ret []Identifier, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	d := r.Descriptions[0]
+	add := func(t IdentifierType, values ...string) {
+		for _, v := range values {
+			if v = strings.TrimSpace(v); len(v) > 0 {
+				ret = append(ret, Identifier{t, v})
+			}
+		}
+	}
+
+	add(IdentifierNCID, d.NCID)
+	if isbns, ok := r.ISBNs(); ok {
+		for _, isbn := range isbns {
+			add(IdentifierISBN, string(isbn))
+		}
+	}
+	for _, issn := range d.ISSN {
+		add(IdentifierISSN, formatISSN(strings.TrimSpace(issn)))
+	}
+	add(IdentifierNBN, d.NBN...)
+	for _, id := range d.Identifier {
+		v := id.Value()
+		lower := strings.ToLower(v)
+		switch {
+		case strings.HasPrefix(lower, "jpno:"):
+			add(IdentifierJPNO, v[len("jpno:"):])
+		case strings.HasPrefix(lower, "jp") && len(v) > 2 && isDigits(v[2:]):
+			add(IdentifierJPNO, v[2:])
+		case strings.HasPrefix(lower, "(ocolc)"):
+			add(IdentifierOCLC, v[len("(ocolc)"):])
+		}
+	}
+	add(IdentifierNDLCallNumber, d.NDLCallNumber...)
+	add(IdentifierOCLC, d.OCLC...)
+	add(IdentifierLCCN, d.LCCN...)
+	return ret, len(ret) > 0
+}
+
+// isDigits は文字列が数字のみからなるか判定する関数
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
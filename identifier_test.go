@@ -0,0 +1,145 @@
+package cinii
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Identifier
+		wantErr bool
+	}{
+		{in: "BN12345678", want: Identifier{IdentifierNCID, "BN12345678"}},
+		{in: " bn1234567x ", want: Identifier{IdentifierNCID, "BN1234567X"}},
+		{in: "DA00000001", want: Identifier{IdentifierAuthorID, "DA00000001"}},
+		{in: "fa000001", want: Identifier{IdentifierFAID, "FA000001"}},
+		{in: "1130000794132829440", want: Identifier{IdentifierCRID, "1130000794132829440"}},
+		{in: "110000123456", want: Identifier{IdentifierNAID, "110000123456"}},
+		{in: "1100001234", want: Identifier{IdentifierNAID, "1100001234"}},
+		{in: "4101010013", want: Identifier{IdentifierISBN, "4101010013"}},
+		{in: "4-10-101001-3", want: Identifier{IdentifierISBN, "4101010013"}},
+		{in: "080442957x", want: Identifier{IdentifierISBN, "080442957X"}},
+		{in: "978-4-10-101001-4", want: Identifier{IdentifierISBN, "9784101010014"}},
+		{in: "urn:isbn:9784101010014", want: Identifier{IdentifierISBN, "9784101010014"}},
+		{in: "ISBN 4-10-101001-3", want: Identifier{IdentifierISBN, "4101010013"}},
+		{in: "isbn:4101010014", want: Identifier{IdentifierISBN, "4101010014"}},
+		{in: "0028-0836", want: Identifier{IdentifierISSN, "0028-0836"}},
+		{in: "issn:00280836", want: Identifier{IdentifierISSN, "0028-0836"}},
+		{in: "urn:issn:0028-083x", want: Identifier{IdentifierISSN, "0028-083X"}},
+		{in: "https://ci.nii.ac.jp/ncid/bn12345678", want: Identifier{IdentifierNCID, "BN12345678"}},
+		{in: "https://ci.nii.ac.jp/ncid/BN12345678.rdf", want: Identifier{IdentifierNCID, "BN12345678"}},
+		{in: "https://ci.nii.ac.jp/author/da00000001", want: Identifier{IdentifierAuthorID, "DA00000001"}},
+		{in: "https://ci.nii.ac.jp/library/FA000001", want: Identifier{IdentifierFAID, "FA000001"}},
+		{in: "https://ci.nii.ac.jp/naid/110000123456", want: Identifier{IdentifierNAID, "110000123456"}},
+		{in: "https://cir.nii.ac.jp/crid/1130000794132829440", want: Identifier{IdentifierCRID, "1130000794132829440"}},
+		{in: "", wantErr: true},
+		{in: "hello", wantErr: true},
+		{in: "BN1234", wantErr: true},
+		{in: "00280836", wantErr: true},
+		{in: "https://example.com/ncid/BN12345678", wantErr: true},
+		{in: "https://ci.nii.ac.jp/ncid/BN1234%3Cx%3E", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseIdentifier(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseIdentifier(%q) = %v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseIdentifier(%q): %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseIdentifier(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewNCID(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    NCID
+		wantErr bool
+	}{
+		{in: "BA12345678", want: "BA12345678"},
+		{in: "bn1234567x", want: "BN1234567X"},
+		{in: " AN00000001\n", want: "AN00000001"},
+		{in: "", wantErr: true},
+		{in: "BA1234567", wantErr: true},
+		{in: "BA123456789", wantErr: true},
+		{in: "B112345678", wantErr: true},
+		{in: "BA1234567Y", wantErr: true},
+		{in: "BA12345678?appid=x", wantErr: true},
+		{in: "../BA12345678", wantErr: true},
+		{in: "BA1234%2F78", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NewNCID(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewNCID(%q) = %q, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewNCID(%q): %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("NewNCID(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordURL(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "ba12345678", want: RetrieveEndopoint + "/BA12345678"},
+		{in: "https://ci.nii.ac.jp/ncid/ba12345678.rdf", want: "https://ci.nii.ac.jp/ncid/BA12345678"},
+		{in: "http://ci.nii.ac.jp/ncid/BA12345678?lang=en", want: "http://ci.nii.ac.jp/ncid/BA12345678"},
+		{in: "BA12345678/../../naid", wantErr: true},
+		{in: "https://ci.nii.ac.jp/author/DA00000001", wantErr: true},
+		{in: "https://ci.nii.ac.jp/ncid/BA123", wantErr: true},
+		{in: "https://example.com/ncid/BA12345678", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := recordURL(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("recordURL(%q) = %q, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("recordURL(%q): %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("recordURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientGetInvalidNCID(t *testing.T) {
+	requests := 0
+	c := testServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	if _, err := c.Get(context.Background(), "BA1234?x=1"); err == nil {
+		t.Error("Get() with a malformed NCID succeeded")
+	}
+	if requests != 0 {
+		t.Errorf("server received %d requests, want 0", requests)
+	}
+}
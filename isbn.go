@@ -0,0 +1,152 @@
+package cinii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ISBN はハイフンを除いたISBN-10またはISBN-13
+type ISBN string
+
+// NewISBN は文字列を検証し、ハイフンを除いたISBNを返す関数
+func NewISBN(s string) (ISBN, error) {
+	v := normalizeISBN(strings.TrimPrefix(strings.TrimSpace(s), "urn:isbn:"))
+	if !isbnPattern.MatchString(v) || !validISBN(v) {
+		return "", fmt.Errorf("cinii: invalid ISBN: %q", s)
+	}
+	return ISBN(v), nil
+}
+
+// Stringerインターフェースの実装
+func (i ISBN) String() string {
+	return string(i)
+}
+
+// ISBN13 はISBN-13に変換したISBNを返すメソッド
+func (i ISBN) ISBN13() ISBN {
+	if len(i) != 10 {
+		return i
+	}
+	body := "978" + string(i[:9])
+	d, _ := ISBNCheckDigit(body)
+	return ISBN(body + d)
+}
+
+// ISBN10 はISBN-10に変換したISBNを返すメソッド。979で始まるISBN-13は変換できない
+func (i ISBN) ISBN10() (ISBN, bool) {
+	switch {
+	case len(i) == 10:
+		return i, true
+	case len(i) == 13 && strings.HasPrefix(string(i), "978"):
+		body := string(i[3:12])
+		d, _ := ISBNCheckDigit(body)
+		return ISBN(body + d), true
+	}
+	return "", false
+}
+
+// Equal はISBN-10とISBN-13の違いを無視してISBNを比較するメソッド
+func (i ISBN) Equal(other ISBN) bool {
+	return i.ISBN13() == other.ISBN13()
+}
+
+// ISBNs はレコードの巻(hasPart)と識別子(dcterms:identifier)からISBNの配列を重複なく返すメソッド
+func (r *Record) ISBNs() (ret []ISBN, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	d := r.Descriptions[0]
+	values := make([]string, 0, len(d.HasPart)+len(d.Identifier))
+	for _, part := range d.HasPart {
+		values = append(values, part.Resource)
+	}
+	for _, id := range d.Identifier {
+		values = append(values, id.Value())
+	}
+
+	seen := make(map[ISBN]bool)
+	for _, v := range values {
+		id, err := ParseIdentifier(v)
+		if err != nil || id.Type != IdentifierISBN {
+			continue
+		}
+		isbn, err := NewISBN(id.Value)
+		if err != nil || seen[isbn.ISBN13()] {
+			continue
+		}
+		seen[isbn.ISBN13()] = true
+		ret = append(ret, isbn)
+	}
+	return ret, len(ret) > 0
+}
+
+// ISBNCheckDigit はISBN-10の先頭9桁またはISBN-13の先頭12桁を受け取り、チェックディジットを返す関数
+func ISBNCheckDigit(body string) (string, error) {
+	for _, c := range body {
+		if c < '0' || c > '9' {
+			return "", fmt.Errorf("cinii: invalid ISBN body: %q", body)
+		}
+	}
+	switch len(body) {
+	case 9:
+		sum := 0
+		for i := 0; i < 9; i++ {
+			sum += int(body[i]-'0') * (10 - i)
+		}
+		d := (11 - sum%11) % 11
+		if d == 10 {
+			return "X", nil
+		}
+		return strconv.Itoa(d), nil
+	case 12:
+		sum := 0
+		for i := 0; i < 12; i++ {
+			d := int(body[i] - '0')
+			if i%2 == 1 {
+				d *= 3
+			}
+			sum += d
+		}
+		return strconv.Itoa((10 - sum%10) % 10), nil
+	}
+	return "", fmt.Errorf("cinii: invalid ISBN body length: %q", body)
+}
+
+// normalizeISBN はISBNからハイフンと空白を除き大文字にする関数
+func normalizeISBN(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.Replace(s, "-", "", -1)
+	s = strings.Replace(s, " ", "", -1)
+	return strings.ToUpper(s)
+}
+
+// validISBN は正規化済みのISBN-10/ISBN-13のチェックディジットを検証する関数
+func validISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i := 0; i < 10; i++ {
+			d := int(isbn[i] - '0')
+			if isbn[i] == 'X' {
+				if i != 9 {
+					return false
+				}
+				d = 10
+			}
+			sum += d * (10 - i)
+		}
+		return sum%11 == 0
+	case 13:
+		sum := 0
+		for i := 0; i < 13; i++ {
+			d := int(isbn[i] - '0')
+			if i%2 == 1 {
+				d *= 3
+			}
+			sum += d
+		}
+		return sum%10 == 0
+	}
+	return false
+}
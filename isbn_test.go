@@ -0,0 +1,146 @@
+package cinii
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewISBN(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ISBN
+		wantErr bool
+	}{
+		{in: "4101010013", want: "4101010013"},
+		{in: "4-10-101001-3", want: "4101010013"},
+		{in: "080442957x", want: "080442957X"},
+		{in: "978-4-10-101001-4", want: "9784101010014"},
+		{in: "urn:isbn:9784003101018", want: "9784003101018"},
+		{in: " 979 10 327 0115 7 ", want: "9791032701157"},
+		{in: "", wantErr: true},
+		{in: "4101010014", wantErr: true},
+		{in: "9784101010015", wantErr: true},
+		{in: "41010100X3", wantErr: true},
+		{in: "1234567890123", wantErr: true},
+		{in: "410101001", wantErr: true},
+		{in: "abcdefghij", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NewISBN(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewISBN(%q) = %q, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewISBN(%q): %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("NewISBN(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISBNCheckDigit(t *testing.T) {
+	tests := []struct {
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{body: "410101001", want: "3"},
+		{body: "080442957", want: "X"},
+		{body: "400310101", want: "4"},
+		{body: "978410101001", want: "4"},
+		{body: "978400310101", want: "8"},
+		{body: "979103270115", want: "7"},
+		{body: "", wantErr: true},
+		{body: "41010100", wantErr: true},
+		{body: "41010100X", wantErr: true},
+		{body: "97841010100", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			got, err := ISBNCheckDigit(tt.body)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ISBNCheckDigit(%q) = %q, want error", tt.body, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ISBNCheckDigit(%q): %v", tt.body, err)
+			}
+			if got != tt.want {
+				t.Errorf("ISBNCheckDigit(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISBNConversion(t *testing.T) {
+	tests := []struct {
+		isbn   ISBN
+		isbn13 ISBN
+		isbn10 ISBN
+		ok     bool
+	}{
+		{"4101010013", "9784101010014", "4101010013", true},
+		{"9784101010014", "9784101010014", "4101010013", true},
+		{"080442957X", "9780804429573", "080442957X", true},
+		{"9780804429573", "9780804429573", "080442957X", true},
+		{"9791032701157", "9791032701157", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.isbn), func(t *testing.T) {
+			if got := tt.isbn.ISBN13(); got != tt.isbn13 {
+				t.Errorf("ISBN13() = %q, want %q", got, tt.isbn13)
+			}
+			got, ok := tt.isbn.ISBN10()
+			if got != tt.isbn10 || ok != tt.ok {
+				t.Errorf("ISBN10() = %q, %v, want %q, %v", got, ok, tt.isbn10, tt.ok)
+			}
+		})
+	}
+}
+
+func TestISBNEqual(t *testing.T) {
+	tests := []struct {
+		a, b ISBN
+		want bool
+	}{
+		{"4101010013", "9784101010014", true},
+		{"9784101010014", "4101010013", true},
+		{"4101010013", "4101010013", true},
+		{"4101010013", "9784003101018", false},
+		{"9791032701157", "9791032701157", true},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b); got != tt.want {
+			t.Errorf("%q.Equal(%q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRecordISBNs(t *testing.T) {
+	const src = `@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix dcterms: <http://purl.org/dc/terms/> .
+<https://ci.nii.ac.jp/ncid/BA00000001> dc:title "こころ" ;
+    dcterms:hasPart <urn:isbn:4101010013>, <urn:isbn:9784003101018>, <urn:isbn:4101010014> ;
+    dcterms:identifier "978-4-10-101001-4" .`
+	r, err := ParseTurtle([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := r.ISBNs()
+	want := []ISBN{"4101010013", "9784003101018"}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("ISBNs() = %q, %v, want %q", got, ok, want)
+	}
+
+	if got, ok := (&Record{}).ISBNs(); ok || got != nil {
+		t.Errorf("empty record ISBNs() = %q, %v, want nil, false", got, ok)
+	}
+}
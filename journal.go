@@ -0,0 +1,83 @@
+package cinii
+
+import "strings"
+
+// ISSNs はレコードからISSNの配列を返すメソッド
+func (r *Record) ISSNs() (ret []string, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	ret = r.Descriptions[0].ISSN
+	if len(ret) == 0 {
+		return nil, false
+	}
+	return ret, true
+}
+
+// PublicationSpan は雑誌レコードから[刊行開始, 刊行終了]を返すメソッド。刊行中の場合、刊行終了は空
+func (r *Record) PublicationSpan() (ret []string, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	date := strings.TrimSpace(r.Descriptions[0].Date)
+	if len(date) == 0 {
+		return nil, false
+	}
+	ret = make([]string, 2)
+	if i := strings.Index(date, "-"); i >= 0 {
+		ret[0] = strings.TrimSpace(date[:i])
+		ret[1] = strings.TrimSpace(date[i+1:])
+	} else {
+		ret[0] = date
+	}
+	return ret, true
+}
+
+// Frequency は雑誌レコードから刊行頻度を返すメソッド
+func (r *Record) Frequency() (string, bool) {
+	if len(r.Descriptions) == 0 {
+		return "", false
+	}
+	freq := r.Descriptions[0].Frequency
+	return freq, len(freq) > 0
+}
+
+// TitleLink は関連する書誌のタイトルとNCIDの構造体
+type TitleLink struct {
+	Title string
+	NCID  string
+}
+
+// titleLinks はResourceFieldの配列をTitleLinkの配列に変換する関数
+func titleLinks(fields []ResourceField) (ret []TitleLink) {
+	for _, field := range fields {
+		ret = append(ret, TitleLink{field.Title, trimResourceURI(field.Resource)})
+	}
+	return
+}
+
+// JournalDetails は雑誌に固有の情報の構造体
+type JournalDetails struct {
+	ISSN       []string
+	Start      string // 刊行開始
+	End        string // 刊行終了。刊行中の場合は空
+	Frequency  string
+	Preceding  []TitleLink // 前誌
+	Succeeding []TitleLink // 後誌
+}
+
+// JournalDetails は雑誌レコードから雑誌に固有の情報を返すメソッド。雑誌以外のレコードではokはfalse
+func (r *Record) JournalDetails() (details JournalDetails, ok bool) {
+	if len(r.Descriptions) == 0 || r.Kind() != KindJournal {
+		return details, false
+	}
+	d := r.Descriptions[0]
+	details.ISSN, _ = r.ISSNs()
+	if span, ok := r.PublicationSpan(); ok {
+		details.Start, details.End = span[0], span[1]
+	}
+	details.Frequency = strings.TrimSpace(d.Frequency)
+	details.Preceding = titleLinks(d.Replaces)
+	details.Succeeding = titleLinks(d.IsReplacedBy)
+	return details, true
+}
@@ -0,0 +1,209 @@
+package cinii
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GetJSON はレコードIDを受け取り、JSON-LD形式で取得した情報をRecord構造体のポインタで返す関数
+func GetJSON(url string, appid string, opts ...ParseOption) (*Record, error) {
+	body, err := openRecord(url, ".json", appid)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	record, err := decodeJSON(body, newParseConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ParseJSON はJSON-LD形式のRecord情報を含むbyte[]を受け取りRecord構造体のポインタで返す関数
+func ParseJSON(body []byte, opts ...ParseOption) (*Record, error) {
+	return decodeJSON(bytes.NewReader(body), newParseConfig(opts))
+}
+
+// decodeJSON はJSON-LD形式のRecord情報を読み出すio.Readerを受け取り、Record構造体のポインタで返す関数
+func decodeJSON(r io.Reader, config *parseConfig) (*Record, error) {
+	var doc interface{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	p := &jsonldParser{prefixes: make(map[string]string), graph: newNodeGraph()}
+	for k, v := range defaultPrefixes {
+		p.prefixes[k] = v
+	}
+
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		p.context(doc["@context"])
+		if graph, ok := doc["@graph"]; ok {
+			p.topLevel(graph)
+		} else {
+			p.topLevel(doc)
+		}
+	case []interface{}:
+		p.topLevel(doc)
+	default:
+		return nil, fmt.Errorf("cinii: unexpected JSON-LD document: %T", doc)
+	}
+
+	record := p.graph.record(config)
+	if err := config.check(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// jsonldParser はJSON-LDをnodeGraphに変換する構造体
+type jsonldParser struct {
+	prefixes map[string]string
+	graph    *nodeGraph
+	blank    int
+}
+
+// newBlank は新しいブランクノードIDを返すメソッド
+func (p *jsonldParser) newBlank() string {
+	p.blank++
+	return fmt.Sprintf("_:genid%d", p.blank)
+}
+
+// id はノードオブジェクトの@idを展開して返すメソッド。@idがない場合はブランクノードIDとする
+func (p *jsonldParser) id(obj map[string]interface{}) string {
+	if id, ok := obj["@id"].(string); ok && len(id) > 0 {
+		return p.expand(id)
+	}
+	return p.newBlank()
+}
+
+// context は@contextから接頭辞の定義を読み込むメソッド
+func (p *jsonldParser) context(ctx interface{}) {
+	switch ctx := ctx.(type) {
+	case []interface{}:
+		for _, c := range ctx {
+			p.context(c)
+		}
+	case map[string]interface{}:
+		for k, v := range ctx {
+			switch v := v.(type) {
+			case string:
+				p.prefixes[k] = v
+			case map[string]interface{}:
+				if id, ok := v["@id"].(string); ok {
+					p.prefixes[k] = id
+				}
+			}
+		}
+	}
+}
+
+// expand は短縮形のIRIを展開するメソッド
+func (p *jsonldParser) expand(term string) string {
+	if iri, ok := p.prefixes[term]; ok {
+		term = iri
+	}
+	if strings.Contains(term, "://") || strings.HasPrefix(term, "_:") {
+		return term
+	}
+	if i := strings.Index(term, ":"); i > 0 {
+		if ns, ok := p.prefixes[term[:i]]; ok {
+			return ns + term[i+1:]
+		}
+	}
+	return term
+}
+
+// topLevel は最上位のノードを読み込むメソッド
+func (p *jsonldParser) topLevel(v interface{}) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, item := range v {
+			p.topLevel(item)
+		}
+	case map[string]interface{}:
+		p.fill(p.graph.get(p.id(v)), v)
+	}
+}
+
+// fill はJSONオブジェクトの各プロパティをノードに追加するメソッド
+func (p *jsonldParser) fill(n *node, obj map[string]interface{}) {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := obj[key]
+		switch key {
+		case "@type":
+			for _, t := range flatten(value) {
+				if s, ok := t.(string); ok {
+					n.add(nsRDF+"type", nodeValue{IRI: p.expand(s)})
+				}
+			}
+		default:
+			if strings.HasPrefix(key, "@") {
+				continue
+			}
+			pred := p.expand(key)
+			for _, item := range flatten(value) {
+				if v, ok := p.value(item); ok {
+					n.add(pred, v)
+				}
+			}
+		}
+	}
+}
+
+// value はJSONの値を目的語に変換するメソッド
+func (p *jsonldParser) value(item interface{}) (nodeValue, bool) {
+	switch item := item.(type) {
+	case string:
+		return nodeValue{Text: item}, true
+	case float64, bool:
+		return nodeValue{Text: fmt.Sprint(item)}, true
+	case map[string]interface{}:
+		if v, ok := item["@value"]; ok {
+			lang, _ := item["@language"].(string)
+			return nodeValue{Text: fmt.Sprint(v), Lang: lang}, true
+		}
+		n := newNode(p.id(item))
+		p.fill(n, item)
+		if len(n.order) == 0 {
+			return nodeValue{IRI: n.id}, true
+		}
+		return nodeValue{IRI: n.id, Node: n}, true
+	}
+	return nodeValue{}, false
+}
+
+// flatten は配列および@list, @setを展開する関数
+func flatten(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		var ret []interface{}
+		for _, item := range v {
+			ret = append(ret, flatten(item)...)
+		}
+		return ret
+	case map[string]interface{}:
+		if list, ok := v["@list"]; ok {
+			return flatten(list)
+		}
+		if set, ok := v["@set"]; ok {
+			return flatten(set)
+		}
+	case nil:
+		return nil
+	}
+	return []interface{}{v}
+}
@@ -0,0 +1,61 @@
+package cinii
+
+import "strings"
+
+// Kind は資料種別
+type Kind int
+
+// 資料種別の定数
+const (
+	KindUnknown     Kind = iota
+	KindBook             // 図書
+	KindJournal          // 雑誌
+	KindAudioVisual      // 視聴覚資料
+	KindMap              // 地図
+	KindScore            // 楽譜
+	KindElectronic       // 電子リソース
+)
+
+var kindNames = []string{"Unknown", "Book", "Journal", "AudioVisual", "Map", "Score", "Electronic"}
+
+// Stringerインターフェースの実装
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return kindNames[0]
+}
+
+// mediumKinds はdcterms:mediumのタイトルと資料種別の対応
+var mediumKinds = map[string]Kind{
+	"図書":     KindBook,
+	"雑誌":     KindJournal,
+	"視聴覚資料":  KindAudioVisual,
+	"地図":     KindMap,
+	"楽譜":     KindScore,
+	"電子リソース": KindElectronic,
+}
+
+// typeKinds はrdf:typeのURIと資料種別の対応
+var typeKinds = map[string]Kind{
+	nsBIBO + "Book":                KindBook,
+	nsBIBO + "Periodical":          KindJournal,
+	nsBIBO + "Journal":             KindJournal,
+	nsBIBO + "AudioVisualDocument": KindAudioVisual,
+	nsBIBO + "Map":                 KindMap,
+}
+
+// Kind はレコードのdcterms:mediumとrdf:typeから資料種別を返すメソッド
+func (r *Record) Kind() Kind {
+	if len(r.Descriptions) == 0 {
+		return KindUnknown
+	}
+	d := r.Descriptions[0]
+	if kind, ok := mediumKinds[strings.TrimSpace(d.Medium.Title)]; ok {
+		return kind
+	}
+	if kind, ok := typeKinds[canonicalIRI(d.Type.Resource)]; ok {
+		return kind
+	}
+	return KindUnknown
+}
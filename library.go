@@ -0,0 +1,208 @@
+package cinii
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// LibraryEndpoint はCiNii Books所蔵館のURI
+const LibraryEndpoint = "http://ci.nii.ac.jp/library"
+
+// Library は所蔵館（参加組織）の構造体
+type Library struct {
+	FAID       string
+	URL        string
+	Name       string
+	Variants   []TextField // 名称の異なり形。読みや英語名はLangを持つ
+	Prefecture string      // 都道府県
+	Address    string
+	OPAC       string  // OPACのベースURL
+	Latitude   float64 // 緯度。不明な場合は0
+	Longitude  float64 // 経度。不明な場合は0
+	Graph      Graph
+}
+
+// nsGeo はW3C Basic Geo Vocabularyの名前空間
+const nsGeo = "http://www.w3.org/2003/01/geo/wgs84_pos#"
+
+// 所蔵館の項目を表す述語の候補
+var (
+	libraryPrefecturePredicates = []string{nsCiNii + "prefecture", "http://www.w3.org/2006/vcard/ns#region"}
+	libraryAddressPredicates    = []string{nsCiNii + "address", "http://www.w3.org/2006/vcard/ns#street-address"}
+	libraryOPACPredicates       = []string{nsCiNii + "opacURL", nsCiNii + "opac", nsFOAF + "homepage"}
+)
+
+// prefectures は都道府県名
+var prefectures = []string{
+	"北海道", "青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
+	"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県",
+	"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県", "岐阜県",
+	"静岡県", "愛知県", "三重県", "滋賀県", "京都府", "大阪府", "兵庫県",
+	"奈良県", "和歌山県", "鳥取県", "島根県", "岡山県", "広島県", "山口県",
+	"徳島県", "香川県", "愛媛県", "高知県", "福岡県", "佐賀県", "長崎県",
+	"熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県",
+}
+
+// prefectureOf は住所の先頭から都道府県名を返す関数。"〒123-4567"等の郵便番号は除いて判定する
+func prefectureOf(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(address, "〒") {
+		if i := strings.IndexAny(address, " 　"); i >= 0 {
+			address = strings.TrimSpace(address[i:])
+		}
+	}
+	for _, pref := range prefectures {
+		if strings.HasPrefix(address, pref) {
+			return pref
+		}
+	}
+	return ""
+}
+
+// firstObject は候補の述語のうち最初に値を持つ述語の目的語を返す関数
+func firstObject(g Graph, subject string, predicates []string) string {
+	for _, pred := range predicates {
+		for _, v := range g.Objects(subject, pred) {
+			if v = strings.TrimSpace(v); len(v) > 0 {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// ParseLibrary は所蔵館のRDF/XMLを含むbyte[]を受け取り、Library構造体のポインタで返す関数
+func ParseLibrary(body []byte) (*Library, error) {
+	g, err := parseGraph(body)
+	if err != nil {
+		return nil, err
+	}
+	names := g.Find("", nsFOAF+"name")
+	if len(names) == 0 {
+		return nil, fmt.Errorf("cinii: no library name")
+	}
+	subject := names[0].Subject
+	l := &Library{URL: trimExtension(subject), FAID: trimResourceURI(subject), Graph: g}
+	for _, t := range g.Find(subject, nsFOAF+"name") {
+		text := strings.TrimSpace(t.Object)
+		switch {
+		case len(text) == 0:
+		case len(t.Lang) == 0 && len(l.Name) == 0:
+			l.Name = text
+		default:
+			l.Variants = append(l.Variants, TextField{Lang: t.Lang, Text: text})
+		}
+	}
+	l.Address = firstObject(g, subject, libraryAddressPredicates)
+	l.Prefecture = firstObject(g, subject, libraryPrefecturePredicates)
+	if len(l.Prefecture) == 0 {
+		l.Prefecture = prefectureOf(l.Address)
+	}
+	l.OPAC = firstObject(g, subject, libraryOPACPredicates)
+	lat, errLat := strconv.ParseFloat(firstObject(g, subject, []string{nsGeo + "lat"}), 64)
+	lon, errLon := strconv.ParseFloat(firstObject(g, subject, []string{nsGeo + "long"}), 64)
+	if errLat == nil && errLon == nil {
+		l.Latitude, l.Longitude = lat, lon
+	}
+	return l, nil
+}
+
+// libraryURL はFAIDまたは所蔵館のURLを検証し、拡張子を除いた所蔵館のURLを返す関数
+func libraryURL(s string) (string, error) {
+	if !strings.Contains(s, "://") {
+		id := strings.ToUpper(strings.TrimSpace(s))
+		if !faidPattern.MatchString(id) {
+			return "", fmt.Errorf("cinii: invalid FAID: %q", s)
+		}
+		return LibraryEndpoint + "/" + id, nil
+	}
+	t, id, err := ParseResourceURL(s)
+	if err != nil {
+		return "", err
+	}
+	if t != ResourceLibrary {
+		return "", fmt.Errorf("cinii: not a library URL: %s", s)
+	}
+	return resourceURL(s, t, id)
+}
+
+// GetLibrary はFAIDまたは所蔵館のURLを受け取り、所蔵館の情報をLibrary構造体のポインタで返すメソッド。
+// 取得した所蔵館はClientにキャッシュし、同じ所蔵館は一度だけ取得する
+func (c *Client) GetLibrary(ctx context.Context, id string) (*Library, error) {
+	u, err := libraryURL(id)
+	if err != nil {
+		return nil, err
+	}
+	faid := trimResourceURI(u)
+	if l, ok := c.cachedLibrary(faid); ok {
+		return l, nil
+	}
+
+	body, err := c.open(ctx, u+".rdf")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	l, err := ParseLibrary(data)
+	if err != nil {
+		return nil, err
+	}
+	c.cacheLibrary(faid, l)
+	return l, nil
+}
+
+// EnrichedHolding は所蔵館の情報を付加した所蔵の構造体
+type EnrichedHolding struct {
+	Name    string   // 所蔵館名
+	FAID    string   // 所蔵館ID
+	OPACURL string   // 所蔵館OPACにおけるこの書誌のURL
+	Library *Library // 所蔵館の情報。FAIDがない場合はnil
+}
+
+// EnrichHoldings はレコードの所蔵館の情報を並行して取得し、Holdings()と同じ順序で所蔵の配列を返すメソッド。
+// 同じ所蔵館はclientのキャッシュにより一度だけ取得する
+func (r *Record) EnrichHoldings(ctx context.Context, client *Client) ([]EnrichedHolding, error) {
+	holdings, ok := r.Holdings()
+	if !ok {
+		return nil, nil
+	}
+	if client == nil {
+		client = &Client{}
+	}
+
+	ret := make([]EnrichedHolding, len(holdings))
+	var faids []string
+	seen := make(map[string]bool)
+	for i, h := range holdings {
+		ret[i] = EnrichedHolding{Name: h[0], FAID: h[1], OPACURL: h[2]}
+		if len(h[1]) > 0 && !seen[h[1]] {
+			seen[h[1]] = true
+			faids = append(faids, h[1])
+		}
+	}
+
+	libraries := make([]*Library, len(faids))
+	err := client.parallel(ctx, len(faids), func(ctx context.Context, i int) (err error) {
+		libraries[i], err = client.GetLibrary(ctx, faids[i])
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	byFAID := make(map[string]*Library, len(faids))
+	for i, faid := range faids {
+		byFAID[faid] = libraries[i]
+	}
+	for i := range ret {
+		ret[i].Library = byFAID[ret[i].FAID]
+	}
+	return ret, nil
+}
@@ -0,0 +1,108 @@
+package cinii
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrNotFound は検索結果に該当する書誌がない場合のエラー
+var ErrNotFound = errors.New("cinii: not found")
+
+// GetByISBN はISBNを受け取り、OpenSearchで検索した書誌の情報をRecord構造体のポインタで返す関数
+func GetByISBN(isbn string, appid string) (*Record, error) {
+	return NewClient(appid).GetByISBN(context.Background(), isbn)
+}
+
+// GetByISBN はISBNを受け取り、OpenSearchで検索した書誌の情報をRecord構造体のポインタで返すメソッド
+func (c *Client) GetByISBN(ctx context.Context, isbn string) (*Record, error) {
+	entry, err := c.findByISBN(ctx, isbn)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Fetch(ctx, c)
+}
+
+// findByISBN はISBNをOpenSearchで検索し、ISBNが一致する巻を持つ最初のエントリを返すメソッド。
+// ISBNはISBN-13に揃えて比較し、一致するエントリがない場合はErrNotFoundを返す
+func (c *Client) findByISBN(ctx context.Context, isbn string) (*Entry, error) {
+	want, err := NewISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	q.Set("isbn", want.String())
+
+	feed, err := c.Search(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	for i := range feed.Entries {
+		if feed.Entries[i].hasISBN(want) {
+			return &feed.Entries[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// GetByISSN はISSNを受け取り、雑誌をOpenSearchで検索した書誌の情報をRecord構造体のポインタで返す関数
+func GetByISSN(issn string, appid string) (*Record, error) {
+	return NewClient(appid).GetByISSN(context.Background(), issn)
+}
+
+// GetByISSN はISSNを受け取り、雑誌をOpenSearchで検索した書誌の情報をRecord構造体のポインタで返すメソッド
+func (c *Client) GetByISSN(ctx context.Context, issn string) (*Record, error) {
+	entry, err := c.findByISSN(ctx, issn)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Fetch(ctx, c)
+}
+
+// findByISSN はISSNで雑誌をOpenSearchで検索し、ISSNが一致する最初のエントリを返すメソッド。
+// 一致するエントリがない場合はErrNotFoundを返す
+func (c *Client) findByISSN(ctx context.Context, issn string) (*Entry, error) {
+	want := formatISSN(strings.ToUpper(strings.TrimSpace(issn)))
+	if !issnPattern.MatchString(want) {
+		return nil, fmt.Errorf("cinii: invalid ISSN: %q", issn)
+	}
+	q := url.Values{}
+	q.Set("issn", want)
+	// 雑誌に限定
+	q.Set("type", "2")
+
+	feed, err := c.Search(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	for i := range feed.Entries {
+		if feed.Entries[i].hasISSN(want) {
+			return &feed.Entries[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// hasISBN はエントリがISBNの一致する巻を含むか判定するメソッド
+func (e *Entry) hasISBN(isbn ISBN) bool {
+	isbns, _ := e.ISBNs()
+	for _, part := range isbns {
+		if part.Equal(isbn) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasISSN はエントリが"1234-567X"の形式のISSNを持つか判定するメソッド
+func (e *Entry) hasISSN(issn string) bool {
+	issns, _ := e.ISSNs()
+	for _, v := range issns {
+		if strings.EqualFold(v, issn) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package cinii
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc は関数をhttp.RoundTripperとして使うための型
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// lookupClient はOpenSearchの検索にfeedを返し、書誌の取得にtestdata/BA12345678.rdfを返すClientを返す関数。
+// 取得した書誌のURLのパスはfetchedに記録する
+func lookupClient(t *testing.T, feed string, fetched *[]string) *Client {
+	t.Helper()
+	record, err := os.ReadFile("testdata/BA12345678.rdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := feed
+		if strings.HasSuffix(req.URL.Path, ".rdf") {
+			*fetched = append(*fetched, req.URL.Path)
+			body = string(record)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/xml; charset=UTF-8"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}}
+}
+
+// atomFeed はエントリの要素からOpenSearchのAtomフィードを組み立てる関数
+func atomFeed(entries ...string) string {
+	return `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:prism="http://prismstandard.org/namespaces/basic/2.0/">` +
+		strings.Join(entries, "") + `</feed>`
+}
+
+func TestGetByISBN(t *testing.T) {
+	feed := atomFeed(
+		`<entry><id>https://ci.nii.ac.jp/ncid/BA00000001</id><dcterms:hasPart>urn:isbn:9784101010014</dcterms:hasPart></entry>`,
+		`<entry><id>https://ci.nii.ac.jp/ncid/BA12345678</id><dcterms:hasPart>urn:isbn:9784003101018</dcterms:hasPart></entry>`,
+	)
+	tests := []struct {
+		name  string
+		isbn  string
+		fetch string
+		err   error
+	}{
+		{"isbn-13", "978-4-00-310101-8", "/ncid/BA12345678.rdf", nil},
+		{"isbn-10 against isbn-13", "4003101014", "/ncid/BA12345678.rdf", nil},
+		{"no matching entry", "9784480020000", "", ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []string
+			c := lookupClient(t, feed, &fetched)
+			r, err := c.GetByISBN(context.Background(), tt.isbn)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) || r != nil || len(fetched) > 0 {
+					t.Errorf("GetByISBN(%q) = %v, %v, fetched %q; want %v", tt.isbn, r, err, fetched, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(fetched) != 1 || fetched[0] != tt.fetch {
+				t.Errorf("fetched %q, want %q", fetched, tt.fetch)
+			}
+		})
+	}
+
+	if _, err := lookupClient(t, feed, new([]string)).GetByISBN(context.Background(), "4003101010"); err == nil {
+		t.Error("GetByISBN with an invalid check digit succeeded")
+	}
+}
+
+func TestGetByISSN(t *testing.T) {
+	feed := atomFeed(
+		`<entry><id>https://ci.nii.ac.jp/ncid/AA00000001</id><prism:issn>0000-0019</prism:issn></entry>`,
+		`<entry><id>https://ci.nii.ac.jp/ncid/BA12345678</id><prism:issn>0386-216X</prism:issn></entry>`,
+	)
+	tests := []struct {
+		name  string
+		issn  string
+		fetch string
+		err   error
+	}{
+		{"hyphenated", "0386-216X", "/ncid/BA12345678.rdf", nil},
+		{"lower case without hyphen", "0386216x", "/ncid/BA12345678.rdf", nil},
+		{"no matching entry", "1234-5679", "", ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []string
+			c := lookupClient(t, feed, &fetched)
+			r, err := c.GetByISSN(context.Background(), tt.issn)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) || r != nil || len(fetched) > 0 {
+					t.Errorf("GetByISSN(%q) = %v, %v, fetched %q; want %v", tt.issn, r, err, fetched, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(fetched) != 1 || fetched[0] != tt.fetch {
+				t.Errorf("fetched %q, want %q", fetched, tt.fetch)
+			}
+		})
+	}
+
+	if _, err := lookupClient(t, feed, new([]string)).GetByISSN(context.Background(), "386-216X"); err == nil {
+		t.Error("GetByISSN with a malformed ISSN succeeded")
+	}
+}
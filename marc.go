@@ -0,0 +1,292 @@
+package cinii
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strings"
+)
+
+// MARCRecord はMARC21のレコード構造体
+type MARCRecord struct {
+	XMLName       xml.Name           `xml:"record"`
+	Leader        string             `xml:"leader"`
+	ControlFields []MARCControlField `xml:"controlfield"`
+	DataFields    []MARCDataField    `xml:"datafield"`
+}
+
+// MARCControlField はMARC21の制御フィールド構造体
+type MARCControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+// MARCDataField はMARC21のデータフィールド構造体
+type MARCDataField struct {
+	Tag       string         `xml:"tag,attr"`
+	Ind1      string         `xml:"ind1,attr"`
+	Ind2      string         `xml:"ind2,attr"`
+	Subfields []MARCSubfield `xml:"subfield"`
+}
+
+// MARCSubfield はMARC21のサブフィールド構造体
+type MARCSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// marcTypes は資料種別とリーダーのレコード種別（06）の対応
+var marcTypes = map[Kind]byte{
+	KindMap:         'e',
+	KindScore:       'c',
+	KindAudioVisual: 'g',
+	KindElectronic:  'm',
+}
+
+// addControl は制御フィールドを追加するメソッド。固定長の値があるため空白は除かず、値が空なら追加しない
+func (m *MARCRecord) addControl(tag, value string) {
+	if len(strings.TrimSpace(value)) > 0 {
+		m.ControlFields = append(m.ControlFields, MARCControlField{tag, value})
+	}
+}
+
+// addData は"コード, 値"を交互に並べたサブフィールドからデータフィールドを追加するメソッド。
+// 値が空のサブフィールドは除き、サブフィールドがなければ追加しない
+func (m *MARCRecord) addData(tag, ind1, ind2 string, codeValues ...string) {
+	field := MARCDataField{Tag: tag, Ind1: ind1, Ind2: ind2}
+	for i := 0; i+1 < len(codeValues); i += 2 {
+		if v := strings.TrimSpace(codeValues[i+1]); len(v) > 0 {
+			field.Subfields = append(field.Subfields, MARCSubfield{codeValues[i], v})
+		}
+	}
+	if len(field.Subfields) > 0 {
+		m.DataFields = append(m.DataFields, field)
+	}
+}
+
+// marcFixedField はMARC21の008フィールド（40桁）を返す関数
+func marcFixedField(date, language string) string {
+	field := []byte(strings.Repeat(" ", 40))
+	if year := firstYear(date); len(year) > 0 {
+		field[6] = 's'
+		copy(field[7:11], year)
+	}
+	if language = strings.TrimSpace(language); len(language) == 3 {
+		copy(field[35:38], language)
+	}
+	return string(field)
+}
+
+// MARC はレコードをMARC21のレコードに変換するメソッド。データフィールドはタグの昇順に並べる。
+// 著者は個人名を100/700、団体名を110/710とする。所蔵は852フィールドとし、$aにFAID、$bに所蔵館名、$uにOPACのURLを格納する。
+// Descriptionのないレコードはゼロ値を返す
+func (r *Record) MARC() MARCRecord {
+	if len(r.Descriptions) == 0 {
+		return MARCRecord{}
+	}
+	d := r.Descriptions[0]
+	kind := r.Kind()
+
+	leader := []byte("00000nam a2200000   4500")
+	if t, ok := marcTypes[kind]; ok {
+		leader[6] = t
+	}
+	if kind == KindJournal {
+		leader[7] = 's'
+	}
+
+	m := MARCRecord{Leader: string(leader)}
+	m.addControl("001", d.NCID)
+	if len(d.NCID) > 0 {
+		m.addControl("003", "CiNii")
+	}
+	m.addControl("008", marcFixedField(d.Date, d.Language))
+
+	for _, lccn := range d.LCCN {
+		m.addData("010", " ", " ", "a", lccn)
+	}
+	for _, nbn := range d.NBN {
+		m.addData("015", " ", " ", "a", nbn, "2", "jnb")
+	}
+	if isbns, ok := r.ISBNs(); ok {
+		for _, isbn := range isbns {
+			m.addData("020", " ", " ", "a", string(isbn))
+		}
+	}
+	if issns, ok := r.ISSNs(); ok {
+		for _, issn := range issns {
+			m.addData("022", " ", " ", "a", issn)
+		}
+	}
+	for _, oclc := range d.OCLC {
+		m.addData("035", " ", " ", "a", "(OCoLC)"+strings.TrimSpace(oclc))
+	}
+	if len(d.NCID) > 0 {
+		m.addData("035", " ", " ", "a", "(NCID)"+d.NCID)
+	}
+	m.addData("041", "0", " ", "a", d.Language)
+	if classifications, ok := r.Classifications(); ok {
+		for _, c := range classifications {
+			m.addData("084", " ", " ", "a", c.Value, "2", strings.ToLower(c.Scheme))
+		}
+	}
+
+	authors, _ := r.Authors()
+	for i, author := range authors {
+		var id string
+		if len(author[2]) > 0 {
+			id = "(CiNii)" + author[2]
+		}
+		// 個人名は100/700とし、姓名に分けられる場合は第1指示子を姓（1）、それ以外は名（0）とする。
+		// 団体名は110/710とし、第1指示子を直接形（2）とする
+		name := ParseName(author[0])
+		tag, ind1 := "700", "0"
+		switch {
+		case name.Corporate:
+			tag, ind1 = "710", "2"
+		case len(name.Family) > 0 && len(name.Given) > 0:
+			ind1 = "1"
+		}
+		if i == 0 {
+			tag = "1" + tag[1:]
+		}
+		m.addData(tag, ind1, " ", "a", name.Format(NameInverted), "d", name.Dates, "0", id)
+	}
+
+	title, _ := r.TitleWithReading()
+	ind1 := "0"
+	if len(authors) > 0 {
+		ind1 = "1"
+	}
+	m.addData("245", ind1, "0", "a", title.Title, "c", d.Creator)
+	if len(title.Reading) > 0 {
+		m.addData("246", "3", "3", "a", title.Reading)
+	}
+	if alternatives, ok := r.AlternativeTitles(); ok {
+		for _, t := range alternatives {
+			m.addData("246", "3", " ", "a", t.Title)
+		}
+	}
+	m.addData("250", " ", " ", "a", d.Edition)
+
+	publishers, _ := r.Publishers()
+	if len(publishers) == 0 {
+		publishers = []Publisher{{}}
+	}
+	for i, p := range publishers {
+		var date string
+		if i == 0 {
+			date = d.Date
+		}
+		m.addData("260", " ", " ", "a", p.Place, "b", p.Name, "c", date)
+	}
+
+	if pd, ok := r.PhysicalDescription(); ok {
+		m.addData("300", " ", " ", "a", pd.Extent, "c", pd.Size, "e", strings.Join(pd.Accompanying, " ; "))
+	}
+	if kind == KindJournal {
+		m.addData("310", " ", " ", "a", d.Frequency)
+	}
+	if series, ok := r.SeriesInfo(); ok {
+		for _, s := range series {
+			m.addData("490", "0", " ", "a", s.Title, "v", s.Volume)
+		}
+	}
+	if notes, ok := r.Notes(); ok {
+		for _, note := range notes {
+			m.addData("500", " ", " ", "a", note)
+		}
+	}
+	if works, ok := r.Works(); ok {
+		titles := make([]string, len(works))
+		for i, w := range works {
+			titles[i] = w.Title
+			if len(w.Author) > 0 {
+				titles[i] += " / " + w.Author
+			}
+		}
+		m.addData("505", "0", " ", "a", strings.Join(titles, " -- "))
+	}
+	if headings, ok := r.SubjectHeadings(); ok {
+		for _, h := range headings {
+			m.addData("650", " ", "7", "a", h.Heading, "2", strings.ToLower(h.Scheme), "0", h.URI)
+		}
+	}
+	if topics, ok := r.Topics(); ok {
+		for _, topic := range topics {
+			m.addData("653", " ", " ", "a", topic)
+		}
+	}
+	if holdings, ok := r.Holdings(); ok {
+		for _, holding := range holdings {
+			m.addData("852", " ", " ", "a", holding[1], "b", holding[0], "u", holding[2])
+		}
+	}
+	if len(d.NCID) > 0 {
+		m.addData("856", "4", "1", "u", NCID(d.NCID).URL())
+	}
+
+	// データフィールドはタグの昇順とし、同じタグの中では追加順を保つ
+	sort.SliceStable(m.DataFields, func(i, j int) bool { return m.DataFields[i].Tag < m.DataFields[j].Tag })
+	return m
+}
+
+// marcCollection はMARCXMLのcollection要素の構造体
+type marcCollection struct {
+	XMLName xml.Name     `xml:"http://www.loc.gov/MARC21/slim collection"`
+	Records []MARCRecord `xml:"record"`
+}
+
+// ToMARCXML はレコードの配列をMARCXMLのcollection要素に変換する関数。nilとDescriptionのないレコードは除く
+func ToMARCXML(records ...*Record) ([]byte, error) {
+	collection := marcCollection{Records: make([]MARCRecord, 0, len(records))}
+	for _, record := range records {
+		if record == nil || len(record.Descriptions) == 0 {
+			continue
+		}
+		collection.Records = append(collection.Records, record.MARC())
+	}
+	body, err := xml.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// marcJSONDataField はMARC-in-JSONのデータフィールドの構造体
+type marcJSONDataField struct {
+	Ind1      string              `json:"ind1"`
+	Ind2      string              `json:"ind2"`
+	Subfields []map[string]string `json:"subfields"`
+}
+
+// MarshalJSON はMARC-in-JSON形式に変換するメソッド
+func (m MARCRecord) MarshalJSON() ([]byte, error) {
+	fields := make([]map[string]interface{}, 0, len(m.ControlFields)+len(m.DataFields))
+	for _, f := range m.ControlFields {
+		fields = append(fields, map[string]interface{}{f.Tag: f.Value})
+	}
+	for _, f := range m.DataFields {
+		subfields := make([]map[string]string, len(f.Subfields))
+		for i, s := range f.Subfields {
+			subfields[i] = map[string]string{s.Code: s.Value}
+		}
+		fields = append(fields, map[string]interface{}{f.Tag: marcJSONDataField{f.Ind1, f.Ind2, subfields}})
+	}
+	return json.Marshal(struct {
+		Leader string                   `json:"leader"`
+		Fields []map[string]interface{} `json:"fields"`
+	}{m.Leader, fields})
+}
+
+// ToMARCJSON はレコードの配列をMARC-in-JSONの配列に変換する関数。nilとDescriptionのないレコードは除く
+func ToMARCJSON(records ...*Record) ([]byte, error) {
+	marcs := make([]MARCRecord, 0, len(records))
+	for _, record := range records {
+		if record == nil || len(record.Descriptions) == 0 {
+			continue
+		}
+		marcs = append(marcs, record.MARC())
+	}
+	return json.Marshal(marcs)
+}
@@ -0,0 +1,29 @@
+package cinii
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMARC(t *testing.T) {
+	m := corporateRecord(t).MARC()
+
+	for i, f := range m.DataFields {
+		if i > 0 && m.DataFields[i-1].Tag > f.Tag {
+			t.Errorf("datafield %s follows %s", f.Tag, m.DataFields[i-1].Tag)
+		}
+	}
+	want := []MARCDataField{
+		{Tag: "110", Ind1: "2", Ind2: " ", Subfields: []MARCSubfield{{"a", "Oxford University Press"}, {"0", "(CiNii)DA00000002"}}},
+		{Tag: "700", Ind1: "1", Ind2: " ", Subfields: []MARCSubfield{{"a", "Smith, John"}, {"0", "(CiNii)DA00000003"}}},
+	}
+	var got []MARCDataField
+	for _, f := range m.DataFields {
+		if f.Tag[1:] == "00" || f.Tag[1:] == "10" {
+			got = append(got, f)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("name fields = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package cinii
+
+import "encoding/json"
+
+// recordJSON はRecord.MarshalJSONが出力するJSONの構造体
+type recordJSON struct {
+	NCID      string        `json:"ncid,omitempty"`
+	Title     string        `json:"title"`
+	Reading   string        `json:"reading,omitempty"`
+	Authors   []authorJSON  `json:"authors,omitempty"`
+	Publisher []string      `json:"publisher,omitempty"`
+	Date      string        `json:"date,omitempty"`
+	ISBNs     []string      `json:"isbns,omitempty"`
+	Holdings  []holdingJSON `json:"holdings,omitempty"`
+}
+
+type authorJSON struct {
+	Name    string `json:"name"`
+	Reading string `json:"reading,omitempty"`
+	ID      string `json:"id,omitempty"`
+}
+
+type holdingJSON struct {
+	Name string `json:"name"`
+	ID   string `json:"id,omitempty"`
+	OPAC string `json:"opac,omitempty"`
+}
+
+// MarshalJSON はjson.Marshalerインターフェースの実装。RDFの構造によらない以下の形式で出力する
+//
+//	{
+//	  "ncid": "BB19132110",
+//	  "title": "タイトル",
+//	  "reading": "タイトルの読み",
+//	  "authors": [{"name": "著者名", "reading": "読み", "id": "DA00000000"}],
+//	  "publisher": ["東京 : 出版者"],
+//	  "date": "2015",
+//	  "isbns": ["9784000000000"],
+//	  "holdings": [{"name": "所蔵館名", "id": "FA000000", "opac": "所蔵館OPACのURL"}]
+//	}
+func (r *Record) MarshalJSON() ([]byte, error) {
+	var v recordJSON
+	if len(r.Descriptions) > 0 {
+		d := r.Descriptions[0]
+		v.NCID = d.NCID
+		v.Publisher = d.Publisher
+		v.Date = d.Date
+	}
+	if title, ok := r.TitleWithReading(); ok {
+		v.Title, v.Reading = title.Title, title.Reading
+	}
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			v.Authors = append(v.Authors, authorJSON{author[0], author[1], author[2]})
+		}
+	}
+	if len(r.Descriptions) > 0 {
+		if isbns, ok := r.ISBNs(); ok {
+			for _, isbn := range isbns {
+				v.ISBNs = append(v.ISBNs, string(isbn))
+			}
+		}
+	}
+	if holdings, ok := r.Holdings(); ok {
+		for _, holding := range holdings {
+			v.Holdings = append(v.Holdings, holdingJSON{holding[0], holding[1], holding[2]})
+		}
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,105 @@
+package cinii
+
+// SourcedValue は値とその値を持つ書誌のNCIDの構造体
+type SourcedValue struct {
+	Value   string
+	Sources []string // 値を持つ書誌のNCID
+}
+
+// MergedHolding は所蔵とその所蔵を持つ書誌のNCIDの構造体
+type MergedHolding struct {
+	Name    string
+	FAID    string
+	OPACURL string // 最初に現れた書誌の所蔵館OPACにおけるURL
+	Sources []string
+}
+
+// MergedRecord は関連する複数のレコードをまとめた構造体。各値は最初に現れた順とする
+type MergedRecord struct {
+	NCIDs    []string
+	Titles   []SourcedValue
+	Authors  []SourcedValue
+	ISBNs    []SourcedValue // ISBN-13
+	Topics   []SourcedValue
+	Holdings []MergedHolding
+}
+
+// sourcedSet は値の出現順と出典を記録する集合
+type sourcedSet struct {
+	values []SourcedValue
+	index  map[string]int
+}
+
+// add は値と出典を追加するメソッド。同じ書誌からの同じ値は一度だけ記録する
+func (s *sourcedSet) add(value, source string) int {
+	if s.index == nil {
+		s.index = make(map[string]int)
+	}
+	i, ok := s.index[value]
+	if !ok {
+		i = len(s.values)
+		s.index[value] = i
+		s.values = append(s.values, SourcedValue{Value: value})
+	}
+	v := &s.values[i]
+	if n := len(v.Sources); n == 0 || v.Sources[n-1] != source {
+		v.Sources = append(v.Sources, source)
+	}
+	return i
+}
+
+// MergeRecords はセットの各巻や新旧のNCID等の関連するレコードをまとめ、
+// タイトル、著者、ISBN、件名、所蔵の和集合を値ごとの出典（NCID）付きで返す関数。
+// 所蔵はFAID（ない場合は所蔵館名）で同一とみなす
+func MergeRecords(records ...*Record) *MergedRecord {
+	m := &MergedRecord{}
+	var titles, authors, isbns, topics, holdings sourcedSet
+	var holdingInfo []MergedHolding
+	for _, r := range records {
+		if r == nil || len(r.Descriptions) == 0 {
+			continue
+		}
+		ncid := r.Descriptions[0].NCID
+		m.NCIDs = append(m.NCIDs, ncid)
+		if title, ok := r.TitleWithReading(); ok && len(title.Title) > 0 {
+			titles.add(title.Title, ncid)
+		}
+		if as, ok := r.Authors(); ok {
+			for _, a := range as {
+				authors.add(a[0], ncid)
+			}
+		}
+		if vs, ok := r.ISBNs(); ok {
+			for _, isbn := range vs {
+				isbns.add(string(isbn.ISBN13()), ncid)
+			}
+		}
+		if vs, ok := r.Topics(); ok {
+			for _, topic := range vs {
+				topics.add(topic, ncid)
+			}
+		}
+		if hs, ok := r.Holdings(); ok {
+			for _, h := range hs {
+				key := h[1]
+				if len(key) == 0 {
+					key = h[0]
+				}
+				if i := holdings.add(key, ncid); i == len(holdingInfo) {
+					holdingInfo = append(holdingInfo, MergedHolding{Name: h[0], FAID: h[1], OPACURL: h[2]})
+				}
+			}
+		}
+	}
+	m.Titles, m.Authors, m.ISBNs, m.Topics = titles.values, authors.values, isbns.values, topics.values
+	for i, v := range holdings.values {
+		holdingInfo[i].Sources = v.Sources
+	}
+	m.Holdings = holdingInfo
+	return m
+}
+
+// HeldByAll は所蔵がまとめたすべての書誌を所蔵しているかを返すメソッド
+func (m *MergedRecord) HeldByAll(h MergedHolding) bool {
+	return len(h.Sources) == len(m.NCIDs)
+}
@@ -0,0 +1,86 @@
+package cinii
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// volumeRecord はセットの巻のレコードを返す関数。authorsは著者IDと名前の組、holdingsはFAIDとOPACのURLの組とする
+func volumeRecord(t *testing.T, ncid, title string, isbns []string, authors, holdings [][2]string) *Record {
+	t.Helper()
+	src := fmt.Sprintf(`@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix dcterms: <http://purl.org/dc/terms/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix bibo: <http://purl.org/ontology/bibo/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix cinii: <http://ci.nii.ac.jp/ns/1.0/> .
+<https://ci.nii.ac.jp/ncid/%[1]s> cinii:ncid "%[1]s" ; dc:title "%[2]s" ;
+    foaf:topic <http://id.ndl.go.jp/auth/ndlsh/00000001> .
+<http://id.ndl.go.jp/auth/ndlsh/00000001> dc:title "日本 -- 歴史" .
+`, ncid, title)
+	for _, isbn := range isbns {
+		src += fmt.Sprintf("<https://ci.nii.ac.jp/ncid/%s> dcterms:hasPart <urn:isbn:%s> .\n", ncid, isbn)
+	}
+	for _, a := range authors {
+		src += fmt.Sprintf("<https://ci.nii.ac.jp/ncid/%s> foaf:maker <https://ci.nii.ac.jp/author/%s> .\n", ncid, a[0])
+		src += fmt.Sprintf("<https://ci.nii.ac.jp/author/%s> foaf:name %q .\n", a[0], a[1])
+	}
+	for _, h := range holdings {
+		src += fmt.Sprintf("<https://ci.nii.ac.jp/ncid/%s#holdings> bibo:owner <https://ci.nii.ac.jp/library/%s> .\n", ncid, h[0])
+		src += fmt.Sprintf("<https://ci.nii.ac.jp/library/%s> foaf:name \"%s館\" ; rdfs:seeAlso <%s> .\n", h[0], h[0], h[1])
+	}
+	r, err := ParseTurtle([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestMergeRecords(t *testing.T) {
+	v1 := volumeRecord(t, "BA00000001", "講座日本の歴史 1",
+		[]string{"4101010013"},
+		[][2]string{{"DA00000001", "網野, 善彦"}},
+		[][2]string{{"FA000001", "https://opac.example.jp/1"}, {"FA000002", "https://opac.example.jp/2"}})
+	v2 := volumeRecord(t, "BA00000002", "講座日本の歴史 2",
+		[]string{"9784003101018", "9784101010014"},
+		[][2]string{{"DA00000001", "網野, 善彦"}, {"DA00000002", "石井, 進"}},
+		[][2]string{{"FA000001", "https://opac.example.jp/other"}, {"FA000003", "https://opac.example.jp/3"}})
+
+	m := MergeRecords(v1, nil, &Record{}, v2)
+	both := []string{"BA00000001", "BA00000002"}
+	want := &MergedRecord{
+		NCIDs: both,
+		Titles: []SourcedValue{
+			{"講座日本の歴史 1", []string{"BA00000001"}},
+			{"講座日本の歴史 2", []string{"BA00000002"}},
+		},
+		Authors: []SourcedValue{
+			{"網野, 善彦", both},
+			{"石井, 進", []string{"BA00000002"}},
+		},
+		ISBNs: []SourcedValue{
+			{"9784101010014", both},
+			{"9784003101018", []string{"BA00000002"}},
+		},
+		Topics: []SourcedValue{{"日本 -- 歴史", both}},
+		Holdings: []MergedHolding{
+			{"FA000001館", "FA000001", "https://opac.example.jp/1", both},
+			{"FA000002館", "FA000002", "https://opac.example.jp/2", []string{"BA00000001"}},
+			{"FA000003館", "FA000003", "https://opac.example.jp/3", []string{"BA00000002"}},
+		},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("MergeRecords() = %+v, want %+v", m, want)
+	}
+
+	for i, want := range []bool{true, false, false} {
+		if got := m.HeldByAll(m.Holdings[i]); got != want {
+			t.Errorf("HeldByAll(%s) = %v, want %v", m.Holdings[i].FAID, got, want)
+		}
+	}
+
+	if got := MergeRecords(nil, &Record{}); !reflect.DeepEqual(got, &MergedRecord{}) {
+		t.Errorf("MergeRecords(nil, empty) = %+v, want empty", got)
+	}
+}
@@ -0,0 +1,282 @@
+package cinii
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// modsRecord はMODS 3.xのmods要素の構造体
+type modsRecord struct {
+	XMLName             xml.Name              `xml:"mods"`
+	Version             string                `xml:"version,attr"`
+	TitleInfo           []modsTitleInfo       `xml:"titleInfo"`
+	Names               []modsName            `xml:"name"`
+	TypeOfResource      string                `xml:"typeOfResource,omitempty"`
+	OriginInfo          *modsOriginInfo       `xml:"originInfo"`
+	Language            *modsLanguage         `xml:"language"`
+	PhysicalDescription *modsPhysical         `xml:"physicalDescription"`
+	TableOfContents     string                `xml:"tableOfContents,omitempty"`
+	Notes               []string              `xml:"note"`
+	Subjects            []modsSubject         `xml:"subject"`
+	Classifications     []modsAuthorityString `xml:"classification"`
+	RelatedItems        []modsRelatedItem     `xml:"relatedItem"`
+	Identifiers         []modsTypedString     `xml:"identifier"`
+	Locations           []modsLocation        `xml:"location"`
+	RecordInfo          *modsRecordInfo       `xml:"recordInfo"`
+}
+
+type modsTitleInfo struct {
+	Type         string `xml:"type,attr,omitempty"`
+	DisplayLabel string `xml:"displayLabel,attr,omitempty"`
+	Lang         string `xml:"lang,attr,omitempty"`
+	Title        string `xml:"title"`
+	PartNumber   string `xml:"partNumber,omitempty"`
+}
+
+type modsName struct {
+	Type             string                `xml:"type,attr,omitempty"`
+	AuthorityURI     string                `xml:"authorityURI,attr,omitempty"`
+	ValueURI         string                `xml:"valueURI,attr,omitempty"`
+	NamePart         string                `xml:"namePart"`
+	Role             string                `xml:"role>roleTerm,omitempty"`
+	AlternativeNames []modsAlternativeName `xml:"alternativeName"`
+}
+
+type modsAlternativeName struct {
+	AltType  string `xml:"altType,attr,omitempty"`
+	Script   string `xml:"script,attr,omitempty"`
+	NamePart string `xml:"namePart"`
+}
+
+type modsOriginInfo struct {
+	Places     []string `xml:"place>placeTerm"`
+	Publishers []string `xml:"publisher"`
+	DateIssued string   `xml:"dateIssued,omitempty"`
+	Edition    string   `xml:"edition,omitempty"`
+	Issuance   string   `xml:"issuance,omitempty"`
+	Frequency  string   `xml:"frequency,omitempty"`
+}
+
+type modsLanguage struct {
+	Term modsAuthorityString `xml:"languageTerm"`
+}
+
+type modsPhysical struct {
+	Extents []string `xml:"extent"`
+}
+
+type modsSubject struct {
+	Authority string `xml:"authority,attr,omitempty"`
+	ValueURI  string `xml:"valueURI,attr,omitempty"`
+	Topic     string `xml:"topic"`
+}
+
+type modsAuthorityString struct {
+	Type      string `xml:"type,attr,omitempty"`
+	Authority string `xml:"authority,attr,omitempty"`
+	Value     string `xml:",chardata"`
+}
+
+type modsTypedString struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type modsRelatedItem struct {
+	Type        string            `xml:"type,attr"`
+	TitleInfo   modsTitleInfo     `xml:"titleInfo"`
+	Identifiers []modsTypedString `xml:"identifier"`
+}
+
+type modsLocation struct {
+	PhysicalLocation *modsPhysicalLocation `xml:"physicalLocation"`
+	URL              string                `xml:"url,omitempty"`
+}
+
+type modsPhysicalLocation struct {
+	ValueURI string `xml:"valueURI,attr,omitempty"`
+	Name     string `xml:",chardata"`
+}
+
+type modsRecordInfo struct {
+	Identifier modsSourceString `xml:"recordIdentifier"`
+}
+
+type modsSourceString struct {
+	Source string `xml:"source,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// modsResourceTypes は資料種別とMODSのtypeOfResourceの対応
+var modsResourceTypes = map[Kind]string{
+	KindBook:        "text",
+	KindJournal:     "text",
+	KindAudioVisual: "moving image",
+	KindMap:         "cartographic",
+	KindScore:       "notated music",
+	KindElectronic:  "software, multimedia",
+}
+
+// mods はレコードをMODSのmods要素に変換するメソッド
+func (r *Record) mods() modsRecord {
+	d := r.Descriptions[0]
+	kind := r.Kind()
+	m := modsRecord{Version: "3.7", TypeOfResource: modsResourceTypes[kind]}
+
+	for i, t := range pairReadings(d.Title) {
+		info := modsTitleInfo{Title: t.Title, Lang: t.Lang}
+		if i > 0 {
+			info.Type = "translated"
+		}
+		if len(t.Title) > 0 {
+			m.TitleInfo = append(m.TitleInfo, info)
+		}
+		if len(t.Reading) > 0 {
+			m.TitleInfo = append(m.TitleInfo, modsTitleInfo{Type: "alternative", DisplayLabel: "reading", Title: t.Reading})
+		}
+	}
+	if alternatives, ok := r.AlternativeTitles(); ok {
+		for _, t := range alternatives {
+			if len(t.Title) > 0 {
+				m.TitleInfo = append(m.TitleInfo, modsTitleInfo{Type: "alternative", Lang: t.Lang, Title: t.Title})
+			}
+		}
+	}
+
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			name := modsName{Type: "personal", NamePart: author[0], Role: "author"}
+			// 読みは表示形ではないため、カタカナ（Kana）の別名とする
+			if len(author[1]) > 0 {
+				name.AlternativeNames = []modsAlternativeName{{AltType: "reading", Script: "Kana", NamePart: author[1]}}
+			}
+			if len(author[2]) > 0 {
+				name.AuthorityURI = "https://ci.nii.ac.jp/author"
+				name.ValueURI = "https://ci.nii.ac.jp/author/" + author[2]
+			}
+			m.Names = append(m.Names, name)
+		}
+	}
+
+	origin := &modsOriginInfo{
+		DateIssued: strings.TrimSpace(d.Date),
+		Edition:    strings.TrimSpace(d.Edition),
+		Issuance:   "monographic",
+	}
+	if kind == KindJournal {
+		origin.Issuance = "continuing"
+		origin.Frequency = strings.TrimSpace(d.Frequency)
+	}
+	if publishers, ok := r.Publishers(); ok {
+		for _, p := range publishers {
+			if len(p.Place) > 0 {
+				origin.Places = append(origin.Places, p.Place)
+			}
+			if len(p.Name) > 0 {
+				origin.Publishers = append(origin.Publishers, p.Name)
+			}
+		}
+	}
+	m.OriginInfo = origin
+
+	if lang := strings.TrimSpace(d.Language); len(lang) > 0 {
+		m.Language = &modsLanguage{modsAuthorityString{"code", "iso639-2b", lang}}
+	}
+	if pd, ok := r.PhysicalDescription(); ok {
+		extent := pd.Extent
+		if len(pd.Size) > 0 {
+			extent += " ; " + pd.Size
+		}
+		for _, a := range pd.Accompanying {
+			extent += " + " + a
+		}
+		m.PhysicalDescription = &modsPhysical{[]string{strings.TrimPrefix(extent, " ; ")}}
+	}
+	m.TableOfContents = strings.Join(d.ContentOfWorks, " -- ")
+	m.Notes, _ = r.Notes()
+
+	if headings, ok := r.SubjectHeadings(); ok {
+		for _, h := range headings {
+			m.Subjects = append(m.Subjects, modsSubject{strings.ToLower(h.Scheme), h.URI, h.Heading})
+		}
+	}
+	if topics, ok := r.Topics(); ok {
+		for _, topic := range topics {
+			m.Subjects = append(m.Subjects, modsSubject{Topic: topic})
+		}
+	}
+	if classifications, ok := r.Classifications(); ok {
+		for _, c := range classifications {
+			m.Classifications = append(m.Classifications, modsAuthorityString{Authority: strings.ToLower(c.Scheme), Value: c.Value})
+		}
+	}
+
+	if series, ok := r.SeriesInfo(); ok {
+		for _, s := range series {
+			item := modsRelatedItem{Type: "series", TitleInfo: modsTitleInfo{Title: s.Title, PartNumber: s.Volume}}
+			if len(s.NCID) > 0 {
+				item.Identifiers = []modsTypedString{{"ncid", s.NCID}}
+			}
+			m.RelatedItems = append(m.RelatedItems, item)
+		}
+	}
+	for _, rel := range []struct {
+		typ   string
+		links []TitleLink
+	}{{"preceding", titleLinks(d.Replaces)}, {"succeeding", titleLinks(d.IsReplacedBy)}} {
+		for _, link := range rel.links {
+			item := modsRelatedItem{Type: rel.typ, TitleInfo: modsTitleInfo{Title: link.Title}}
+			if len(link.NCID) > 0 {
+				item.Identifiers = []modsTypedString{{"ncid", link.NCID}}
+			}
+			m.RelatedItems = append(m.RelatedItems, item)
+		}
+	}
+
+	if ids, ok := r.Identifiers(); ok {
+		for _, id := range ids {
+			m.Identifiers = append(m.Identifiers, modsTypedString{strings.ToLower(id.Type.String()), id.Value})
+		}
+	}
+	if len(d.NCID) > 0 {
+		m.Locations = append(m.Locations, modsLocation{URL: NCID(d.NCID).URL()})
+	}
+	if holdings, ok := r.Holdings(); ok {
+		for _, holding := range holdings {
+			var uri string
+			if len(holding[1]) > 0 {
+				uri = "https://ci.nii.ac.jp/library/" + holding[1]
+			}
+			m.Locations = append(m.Locations, modsLocation{
+				PhysicalLocation: &modsPhysicalLocation{uri, holding[0]},
+				URL:              holding[2],
+			})
+		}
+	}
+	if len(d.NCID) > 0 {
+		m.RecordInfo = &modsRecordInfo{modsSourceString{"CiNii", d.NCID}}
+	}
+	return m
+}
+
+// modsCollection はMODSのmodsCollection要素の構造体
+type modsCollection struct {
+	XMLName xml.Name     `xml:"http://www.loc.gov/mods/v3 modsCollection"`
+	Records []modsRecord `xml:"mods"`
+}
+
+// ToMODS はレコードの配列をMODS 3.7のmodsCollection要素に変換する関数。nilとDescriptionのないレコードは除く
+func ToMODS(records ...*Record) ([]byte, error) {
+	collection := modsCollection{Records: make([]modsRecord, 0, len(records))}
+	for _, record := range records {
+		if record == nil || len(record.Descriptions) == 0 {
+			continue
+		}
+		collection.Records = append(collection.Records, record.mods())
+	}
+	body, err := xml.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
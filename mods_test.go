@@ -0,0 +1,24 @@
+package cinii
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMODSNameReading(t *testing.T) {
+	r := parseTestRecord(t, "testdata/BA12345678.rdf", Parse)
+	body, err := ToMODS(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(body)
+	if strings.Contains(got, "displayForm") {
+		t.Errorf("reading is stored in displayForm:\n%s", got)
+	}
+	want := `<alternativeName altType="reading" script="Kana">
+        <namePart>ナツメ, ソウセキ</namePart>
+      </alternativeName>`
+	if !strings.Contains(got, want) {
+		t.Errorf("MODS does not contain %q:\n%s", want, got)
+	}
+}
@@ -0,0 +1,209 @@
+package cinii
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// NameOrder は著者名の表示順
+type NameOrder int
+
+// 著者名の表示順の定数
+const (
+	NameNatural     NameOrder = iota // 和名は"姓名"、欧文名は"名 姓"
+	NameFamilyFirst                  // "姓 名"（和名は区切りなし）
+	NameGivenFirst                   // "名 姓"（和名は区切りなし）
+	NameInverted                     // "姓, 名"
+)
+
+// nameDatesPattern は著者名に付された生没年等のパターン。"1867-1916"、"1950-"、"生没年不詳"等
+var nameDatesPattern = regexp.MustCompile(`^(?:[0-9]{4}\??-?(?:[0-9]{4}\??)?|-[0-9]{4}|[0-9]{2}--|生没年不詳|b\. ?[0-9]{4}|d\. ?[0-9]{4})$`)
+
+// PersonalName は著者名の構造体。姓名に分けられない名前（団体名等）はFamilyのみを持つ
+type PersonalName struct {
+	Family    string
+	Given     string
+	Suffix    string // "Jr."等の敬称
+	Dates     string // 生没年等
+	Corporate bool   // 団体名か。団体名は分割せずFamilyに名前全体を持つ
+}
+
+// corporateKeywords は和文の団体名に含まれる語
+var corporateKeywords = []string{
+	"大学", "学会", "協会", "研究所", "研究会", "委員会", "審議会", "出版", "書店", "書房", "新聞社",
+	"株式会社", "有限会社", "図書館", "博物館", "美術館", "資料館", "文書館", "財団", "法人",
+	"センター", "学校", "連盟", "組合", "会議", "編集部", "編纂所", "政府",
+}
+
+// corporateSuffixes は和文の団体名の末尾の文字。姓や名に多い"部"、"所"等は含めない
+var corporateSuffixes = []string{"省", "庁", "局", "課", "会", "社", "館", "団"}
+
+// corporateWords は欧文の団体名であることが明らかな語（小文字）
+var corporateWords = map[string]bool{
+	"university": true, "universität": true, "université": true, "universidad": true, "università": true,
+	"institute": true, "institut": true, "instituto": true, "academy": true, "association": true,
+	"society": true, "committee": true, "council": true, "commission": true, "ministry": true,
+	"department": true, "foundation": true, "laboratory": true, "laboratories": true, "museum": true,
+	"library": true, "organization": true, "organisation": true, "federation": true, "agency": true,
+	"bureau": true, "publishers": true, "publishing": true, "verlag": true, "company": true,
+	"corporation": true, "corp": true, "inc": true, "ltd": true, "llc": true, "gmbh": true,
+}
+
+// corporateWeakWords は姓にもある欧文の団体名の語（小文字）。
+// 名前の最後の語である場合は、略語や前置詞等を含む場合のみ団体名とする
+var corporateWeakWords = map[string]bool{
+	"press": true, "college": true, "school": true, "center": true, "centre": true, "bank": true,
+	"board": true, "union": true, "group": true, "office": true, "trust": true, "league": true,
+	"service": true, "services": true, "survey": true, "government": true, "nations": true,
+	"conference": true, "congress": true, "symposium": true, "workshop": true,
+}
+
+// corporateFunctionWords は欧文の団体名に含まれ、個人名には含まれにくい前置詞等（小文字）
+var corporateFunctionWords = map[string]bool{
+	"of": true, "and": true, "for": true, "the": true, "&": true, "on": true, "für": true,
+}
+
+// IsCorporateName は名前が団体名か判定する関数。和文は"大学"、"協会"等の語または"省"、"庁"等の末尾で、
+// 欧文は"University"、"Inc."等の語で判定する。欧文の"姓, 名"の形式は個人名とする
+func IsCorporateName(s string) bool {
+	s = strings.TrimSpace(s)
+	if !hasLatin(s) {
+		for _, kw := range corporateKeywords {
+			if strings.Contains(s, kw) {
+				return true
+			}
+		}
+		for _, suffix := range corporateSuffixes {
+			if strings.HasSuffix(s, suffix) && utf8.RuneCountInString(s) > 1 {
+				return true
+			}
+		}
+		return false
+	}
+	if strings.ContainsAny(s, ",，") {
+		return false
+	}
+	words := strings.Fields(s)
+	if len(words) < 2 {
+		return false
+	}
+	var weak, function, acronym bool
+	for i, w := range words {
+		lower := strings.ToLower(strings.Trim(w, ".()"))
+		switch {
+		case corporateWords[lower]:
+			return true
+		case corporateWeakWords[lower] && i < len(words)-1:
+			return true
+		case corporateWeakWords[lower]:
+			weak = true
+		case corporateFunctionWords[lower]:
+			function = true
+		case len(w) > 1 && w == strings.ToUpper(w) && hasLatin(w):
+			acronym = true
+		}
+	}
+	return weak && (function || acronym)
+}
+
+// IsLatin は欧文名かを返すメソッド
+func (n PersonalName) IsLatin() bool {
+	return hasLatin(n.Family + n.Given)
+}
+
+// Format は著者名を表示順に従って組み立てるメソッド。生没年等は含まない
+func (n PersonalName) Format(order NameOrder) string {
+	name := n.format(order)
+	if len(n.Suffix) > 0 {
+		name += ", " + n.Suffix
+	}
+	return name
+}
+
+// format は敬称を除く著者名を表示順に従って組み立てるメソッド
+func (n PersonalName) format(order NameOrder) string {
+	if len(n.Given) == 0 {
+		return n.Family
+	}
+	if len(n.Family) == 0 {
+		return n.Given
+	}
+	sep := ""
+	if n.IsLatin() {
+		sep = " "
+	}
+	switch order {
+	case NameInverted:
+		return n.Family + ", " + n.Given
+	case NameFamilyFirst:
+		return n.Family + sep + n.Given
+	case NameGivenFirst:
+		return n.Given + sep + n.Family
+	}
+	if n.IsLatin() {
+		return n.Given + " " + n.Family
+	}
+	return n.Family + n.Given
+}
+
+// Stringerインターフェースの実装。"姓, 名"の形式で返す
+func (n PersonalName) String() string {
+	return n.Format(NameInverted)
+}
+
+// ParseName はCiNiiの著者名を姓、名、生没年等に分割する関数。
+// "夏目, 漱石, 1867-1916"のようなカンマ区切りの形式のほか、"John Smith"のような欧文名、
+// "夏目 漱石"のような空白区切りの和名を扱う。区切りのない名前と、IsCorporateNameが団体名と判定した
+// "Oxford University Press"のような名前は分割せずFamilyのみとする
+func ParseName(s string) PersonalName {
+	var parts []string
+	for _, p := range strings.FieldsFunc(strings.TrimSpace(s), func(r rune) bool { return r == ',' || r == '，' }) {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			parts = append(parts, p)
+		}
+	}
+	var n PersonalName
+	if len(parts) > 1 && nameDatesPattern.MatchString(parts[len(parts)-1]) {
+		n.Dates = parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+	if name := strings.Join(parts, ", "); IsCorporateName(name) {
+		n.Family, n.Corporate = name, true
+		return n
+	}
+	switch len(parts) {
+	case 0:
+		return n
+	case 1:
+		words := strings.Fields(parts[0])
+		switch {
+		case len(words) < 2:
+			n.Family = parts[0]
+		case hasLatin(parts[0]):
+			n.Family = words[len(words)-1]
+			n.Given = strings.Join(words[:len(words)-1], " ")
+		default:
+			n.Family = words[0]
+			n.Given = strings.Join(words[1:], " ")
+		}
+	default:
+		n.Family = parts[0]
+		n.Given = parts[1]
+		n.Suffix = strings.Join(parts[2:], ", ")
+	}
+	return n
+}
+
+// AuthorNames はレコードの著者名を分割し、Authors()と同じ順序で返すメソッド
+func (r *Record) AuthorNames() (ret []PersonalName, ok bool) {
+	authors, ok := r.Authors()
+	if !ok {
+		return nil, false
+	}
+	ret = make([]PersonalName, len(authors))
+	for i, author := range authors {
+		ret[i] = ParseName(author[0])
+	}
+	return ret, true
+}
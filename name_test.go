@@ -0,0 +1,78 @@
+package cinii
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want PersonalName
+	}{
+		{"夏目, 漱石, 1867-1916", PersonalName{Family: "夏目", Given: "漱石", Dates: "1867-1916"}},
+		{"夏目 漱石", PersonalName{Family: "夏目", Given: "漱石"}},
+		{"Smith, John", PersonalName{Family: "Smith", Given: "John"}},
+		{"John Smith", PersonalName{Family: "Smith", Given: "John"}},
+		{"Press, Frank", PersonalName{Family: "Press", Given: "Frank"}},
+		{"Frank Press", PersonalName{Family: "Press", Given: "Frank"}},
+		{"Oxford University Press", PersonalName{Family: "Oxford University Press", Corporate: true}},
+		{"MIT Press", PersonalName{Family: "MIT Press", Corporate: true}},
+		{"Bank of Japan", PersonalName{Family: "Bank of Japan", Corporate: true}},
+		{"Springer Verlag", PersonalName{Family: "Springer Verlag", Corporate: true}},
+		{"日本図書館協会", PersonalName{Family: "日本図書館協会", Corporate: true}},
+		{"東京大学, 文学部", PersonalName{Family: "東京大学, 文学部", Corporate: true}},
+		{"文部省", PersonalName{Family: "文部省", Corporate: true}},
+		{"浜田 省吾", PersonalName{Family: "浜田", Given: "省吾"}},
+		{"服部", PersonalName{Family: "服部"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := ParseName(tt.in); got != tt.want {
+				t.Errorf("ParseName(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// corporateRecord は団体の著者と個人の著者を持つレコードを返す関数。
+// 所蔵のないレコードは著者を返さないため、所蔵のDescriptionを含める
+func corporateRecord(t *testing.T) *Record {
+	t.Helper()
+	const src = `@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<https://ci.nii.ac.jp/ncid/BA00000001> dc:title "Cataloguing rules" ; dc:date "2001" ;
+    foaf:maker <https://ci.nii.ac.jp/author/DA00000002>, <https://ci.nii.ac.jp/author/DA00000003> .
+<https://ci.nii.ac.jp/author/DA00000002> foaf:name "Oxford University Press" .
+<https://ci.nii.ac.jp/author/DA00000003> foaf:name "Smith, John" .
+<https://ci.nii.ac.jp/ncid/BA00000001#holdings> bibo:owner <https://ci.nii.ac.jp/library/FA000001> .
+<https://ci.nii.ac.jp/library/FA000001> foaf:name "東京大学 総合図書館" .`
+	r, err := ParseTurtle([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestCorporateNameOutput(t *testing.T) {
+	r := corporateRecord(t)
+
+	want := []CSLName{{Literal: "Oxford University Press"}, {Family: "Smith", Given: "John"}}
+	if got := r.CSLItem().Author; !reflect.DeepEqual(got, want) {
+		t.Errorf("CSL author = %+v, want %+v", got, want)
+	}
+	if got, want := r.ToBibTeX(), "author = {{Oxford University Press} and Smith, John}"; !strings.Contains(got, want) {
+		t.Errorf("BibTeX does not contain %q:\n%s", want, got)
+	}
+	if got, want := r.ToRIS(), "AU  - Oxford University Press\r\n"; !strings.Contains(got, want) {
+		t.Errorf("RIS does not contain %q:\n%s", want, got)
+	}
+	got, err := FormatCitation(r, StyleAPA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Oxford University Press, & Smith, J."; !strings.HasPrefix(got, want) {
+		t.Errorf("APA = %q, want prefix %q", got, want)
+	}
+}
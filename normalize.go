@@ -0,0 +1,120 @@
+package cinii
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	combiningVoiced     = '゙' // 結合用濁点
+	combiningSemiVoiced = '゚' // 結合用半濁点
+)
+
+// halfWidthKana は半角カナと全角カナの対応。濁点、半濁点は結合用の文字とする
+var halfWidthKana = func() map[rune]rune {
+	half := []rune("｡｢｣､･ｦｧｨｩｪｫｬｭｮｯｰｱｲｳｴｵｶｷｸｹｺｻｼｽｾｿﾀﾁﾂﾃﾄﾅﾆﾇﾈﾉﾊﾋﾌﾍﾎﾏﾐﾑﾒﾓﾔﾕﾖﾗﾘﾙﾚﾛﾜﾝ")
+	full := []rune("。「」、・ヲァィゥェォャュョッーアイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン")
+	m := make(map[rune]rune, len(half)+2)
+	for i, r := range half {
+		m[r] = full[i]
+	}
+	m['ﾞ'] = combiningVoiced
+	m['ﾟ'] = combiningSemiVoiced
+	m['゛'] = combiningVoiced
+	m['゜'] = combiningSemiVoiced
+	return m
+}()
+
+// compatibilityChars はNFKCで分解される互換文字のうち書誌データに現れやすいもの。NormalizeJaはこの表の文字のみを置き換える
+var compatibilityChars = strings.NewReplacer(
+	"①", "1", "②", "2", "③", "3", "④", "4", "⑤", "5", "⑥", "6", "⑦", "7", "⑧", "8", "⑨", "9", "⑩", "10",
+	"⑪", "11", "⑫", "12", "⑬", "13", "⑭", "14", "⑮", "15", "⑯", "16", "⑰", "17", "⑱", "18", "⑲", "19", "⑳", "20",
+	"Ⅰ", "I", "Ⅱ", "II", "Ⅲ", "III", "Ⅳ", "IV", "Ⅴ", "V", "Ⅵ", "VI", "Ⅶ", "VII", "Ⅷ", "VIII", "Ⅸ", "IX", "Ⅹ", "X", "Ⅺ", "XI", "Ⅻ", "XII",
+	"ⅰ", "i", "ⅱ", "ii", "ⅲ", "iii", "ⅳ", "iv", "ⅴ", "v", "ⅵ", "vi", "ⅶ", "vii", "ⅷ", "viii", "ⅸ", "ix", "ⅹ", "x",
+	"㈱", "(株)", "㈲", "(有)", "㈶", "(財)", "㈳", "(社)",
+	"㍾", "明治", "㍽", "大正", "㍼", "昭和", "㍻", "平成", "㋿", "令和",
+	"㌔", "キロ", "㌘", "グラム", "㌢", "センチ", "㍍", "メートル", "㌫", "パーセント",
+	"№", "No", "℡", "TEL", "™", "TM",
+)
+
+// longVowelVariants は仮名に続く場合に長音符とみなす文字
+const longVowelVariants = "-‐‑‒–—―─━ｰ"
+
+// isKana はひらがなまたはカタカナ（長音符を含む）かを返す関数
+func isKana(r rune) bool {
+	return unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+// composeVoiced は仮名に濁点または半濁点を合成する関数。合成できない場合はfalseを返す
+func composeVoiced(base, mark rune) (rune, bool) {
+	const voiceable = "かきくけこさしすせそたちつてとはひふへほカキクケコサシスセソタチツテトハヒフヘホ"
+	const semiVoiceable = "はひふへほハヒフヘホ"
+	switch {
+	case mark == combiningVoiced && (base == 'ウ' || base == 'う'):
+		return base + ('ヴ' - 'ウ'), true
+	case mark == combiningVoiced && strings.ContainsRune(voiceable, base):
+		return base + 1, true
+	case mark == combiningSemiVoiced && strings.ContainsRune(semiVoiceable, base):
+		return base + 2, true
+	}
+	return 0, false
+}
+
+// NormalizeJa は検索語の作成や、CiNiiの文字列と手元の目録データとの照合のために文字列を正規化する関数。
+// 次の変換を行う。NFKCとは異なり、これ以外の互換文字（㊤、㎏、ℓ等）はそのまま残す。
+// NFKCによる正規化が必要な場合は、golang.org/x/text/unicode/norm等で正規化してから渡す
+//
+//   - 全角英数字記号（！から～）と￥の半角化、全角空白の半角化
+//   - 半角カナ（句読点等を含む）の全角化と、濁点、半濁点の合成（ｶﾞ→ガ、ﾊﾟ→パ、ｳﾞ→ヴ）
+//   - compatibilityCharsの互換文字の置き換え（丸数字①～⑳、ローマ数字Ⅰ～Ⅻ、ⅰ～ⅹ、㈱等の括弧付き文字、
+//     ㍻等の元号の組文字、㌔等の単位の組文字、№、℡、™）
+//   - 仮名に続くハイフン類の長音符への統一
+//   - 空白の連続の1文字への置き換えと前後の空白の除去
+func NormalizeJa(s string) string {
+	s = compatibilityChars.Replace(s)
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case '！' <= r && r <= '～':
+			r = r - '！' + '!'
+		case r == '　' || r == ' ':
+			r = ' '
+		case r == '￥':
+			r = '¥'
+		default:
+			if full, ok := halfWidthKana[r]; ok {
+				r = full
+			}
+		}
+		if r == combiningVoiced || r == combiningSemiVoiced {
+			if n := len(runes); n > 0 {
+				if composed, ok := composeVoiced(runes[n-1], r); ok {
+					runes[n-1] = composed
+					continue
+				}
+			}
+		}
+		if strings.ContainsRune(longVowelVariants, r) {
+			if n := len(runes); n > 0 && isKana(runes[n-1]) {
+				r = 'ー'
+			}
+		}
+		runes = append(runes, r)
+	}
+	return strings.Join(strings.Fields(string(runes)), " ")
+}
+
+// matchKey は照合用に正規化し、大文字小文字、空白、記号の違いを除いた文字列を返す関数
+func matchKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, NormalizeJa(s))
+}
+
+// EqualJa は2つの文字列がNormalizeJaで正規化した上で大文字小文字、空白、記号の違いを除いて等しいかを返す関数
+func EqualJa(a, b string) bool {
+	return matchKey(a) == matchKey(b)
+}
@@ -0,0 +1,45 @@
+package cinii
+
+import "testing"
+
+func TestNormalizeJa(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"fullwidth alphanumerics", "ＡＢＣ　１２３！", "ABC 123!"},
+		{"yen sign", "￥1000", "¥1000"},
+		{"halfwidth kana", "ｶﾀｶﾅ｡", "カタカナ。"},
+		{"halfwidth kana with dakuten", "ｶﾞｯｺｳ ﾄﾞｸﾎﾝ", "ガッコウ ドクホン"},
+		{"halfwidth kana with handakuten", "ﾊﾟﾝ ﾎﾟｽﾄ", "パン ポスト"},
+		{"halfwidth vu", "ｳﾞｧｲｵﾘﾝ", "ヴァイオリン"},
+		{"spacing dakuten", "か゛き", "がき"},
+		{"dakuten that does not compose", "ｱﾞ", "ア゙"},
+		{"circled digits", "第①巻 ⑳", "第1巻 20"},
+		{"roman numerals", "Ⅲ ⅻ Ⅻ ⅳ", "III ⅻ XII iv"},
+		{"parenthesized ideographs", "㈱岩波書店", "(株)岩波書店"},
+		{"era squares", "㍻元年 ㋿2年", "平成元年 令和2年"},
+		{"unit squares", "10㌔", "10キロ"},
+		{"long vowel after kana", "コンピュ-タ‐", "コンピューター"},
+		{"hyphen after latin", "ISBN 4-00-310101", "ISBN 4-00-310101"},
+		{"spaces", "  吾輩は　　猫である ", "吾輩は 猫である"},
+		{"characters outside the table", "㊤㎏ℓ", "㊤㎏ℓ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeJa(tt.in); got != tt.want {
+				t.Errorf("NormalizeJa(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualJa(t *testing.T) {
+	if !EqualJa("ｺﾝﾋﾟｭｰﾀ・サイエンス①", "コンピュータ サイエンス 1") {
+		t.Error("EqualJa returned false")
+	}
+	if EqualJa("ガッコウ", "カッコウ") {
+		t.Error("EqualJa ignored the dakuten")
+	}
+}
@@ -0,0 +1,102 @@
+package cinii
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// oaiDCHeader はoai_dc:dc要素の開始タグ
+const oaiDCHeader = `<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/"` +
+	` xmlns:dc="http://purl.org/dc/elements/1.1/"` +
+	` xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"` +
+	` xsi:schemaLocation="http://www.openarchives.org/OAI/2.0/oai_dc/ http://www.openarchives.org/OAI/2.0/oai_dc.xsd">`
+
+// dcTypes は資料種別とDCMI Type Vocabularyの対応
+var dcTypes = map[Kind]string{
+	KindBook:        "Text",
+	KindJournal:     "Text",
+	KindAudioVisual: "MovingImage",
+	KindMap:         "Image",
+	KindScore:       "Text",
+	KindElectronic:  "Software",
+}
+
+// ToOAIDC はレコードをOAI-PMHのoai_dc形式のDublin Core XMLに変換するメソッド。Descriptionのないレコードは空文字列を返す
+func (r *Record) ToOAIDC() string {
+	if len(r.Descriptions) == 0 {
+		return ""
+	}
+	d := r.Descriptions[0]
+
+	var sb strings.Builder
+	sb.WriteString(oaiDCHeader)
+	sb.WriteString("\n")
+	add := func(name string, values ...string) {
+		for _, v := range values {
+			if v = strings.TrimSpace(v); len(v) == 0 {
+				continue
+			}
+			sb.WriteString("  <dc:" + name + ">")
+			xml.EscapeText(&sb, []byte(v))
+			sb.WriteString("</dc:" + name + ">\n")
+		}
+	}
+
+	for _, t := range pairReadings(d.Title) {
+		add("title", t.Title)
+	}
+	if alternatives, ok := r.AlternativeTitles(); ok {
+		for _, t := range alternatives {
+			add("title", t.Title)
+		}
+	}
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			add("creator", author[0])
+		}
+	} else {
+		add("creator", d.Creator)
+	}
+	if headings, ok := r.SubjectHeadings(); ok {
+		for _, h := range headings {
+			add("subject", h.Heading)
+		}
+	}
+	if topics, ok := r.Topics(); ok {
+		add("subject", topics...)
+	}
+	if classifications, ok := r.Classifications(); ok {
+		for _, c := range classifications {
+			add("subject", c.Scheme+":"+c.Value)
+		}
+	}
+	add("description", d.ContentOfWorks...)
+	if notes, ok := r.Notes(); ok {
+		add("description", notes...)
+	}
+	add("publisher", d.Publisher...)
+	add("date", d.Date)
+	add("type", dcTypes[r.Kind()])
+	if pd, ok := r.PhysicalDescription(); ok {
+		add("format", pd.Extent, pd.Size)
+	}
+	if len(d.NCID) > 0 {
+		add("identifier", NCID(d.NCID).URL())
+	}
+	if ids, ok := r.Identifiers(); ok {
+		for _, id := range ids {
+			switch id.Type {
+			case IdentifierISBN:
+				add("identifier", "urn:isbn:"+id.Value)
+			case IdentifierISSN:
+				add("identifier", "urn:issn:"+id.Value)
+			}
+		}
+	}
+	add("language", d.Language)
+	for _, field := range d.IsPartOf {
+		add("relation", field.Title)
+	}
+	sb.WriteString("</oai_dc:dc>\n")
+	return sb.String()
+}
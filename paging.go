@@ -0,0 +1,208 @@
+package cinii
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// ErrDeadlinePartial はctxの期限までに検索結果のすべてのページを取得できないため、
+// ページの区切りで取得を中止したことを表すエラー。それまでに取得した結果は有効である
+var ErrDeadlinePartial = errors.New("cinii: deadline reached before all pages were fetched")
+
+// ErrMaxResults はClientのMaxResultsの件数に達したため、残りの検索結果があるもののページ送りを中止したことを表すエラー。
+// それまでに取得した結果は有効である
+var ErrMaxResults = errors.New("cinii: maximum number of results reached; more results exist")
+
+// errStop はページ送りを呼び出し側の都合で中止する場合のエラー
+var errStop = errors.New("cinii: stop paging")
+
+// pagingParams は自動のページ送りに用いるSearchParamsと、最初のページで読み飛ばす件数を返す関数。
+// 件数の指定がない場合は1ページをMaxCount件とし、取得する最初の結果の番号はページの先頭に丸める
+func pagingParams(p SearchParams) (SearchParams, int) {
+	if p.Count == 0 {
+		p.Count = MaxCount
+	}
+	aligned := p.Clamp()
+	if p.Start > aligned.Start {
+		return aligned, p.Start - aligned.Start
+	}
+	return aligned, 0
+}
+
+// pageResult は取得したページ、エラー、取得に要した時間の構造体
+type pageResult struct {
+	feed    *AtomFeed
+	err     error
+	elapsed time.Duration
+}
+
+// pendingPage は取得中のページの構造体
+type pendingPage struct {
+	params SearchParams
+	result <-chan pageResult
+}
+
+// eachPage はSearchParamsの条件でOpenSearchの検索結果をページ順に取得し、ページごとに続きを取得するための
+// Cursorとともにfを呼び出すメソッド。取得する最初の結果の番号がページの途中の場合は、それより前のエントリを除いて渡す。
+// 最後のページに達するか、検索またはfがエラーを返した時点で終了する。
+// ClientのPrefetchが正の場合は、最初のページで総件数が分かった後、続くページを並行して先読みする。
+// 先読みしたページもfにはページ順に渡し、リクエストの間隔はMinIntervalに従う。
+// ctxに期限がある場合、次のページの取得が期限までに終わらない見込みになった時点で中止してErrDeadlinePartialを返す。
+// ClientのMaxResultsの件数に達した場合は、超えた分を除いたページをfに渡した後、残りがあればErrMaxResultsを返す
+func (c *Client) eachPage(ctx context.Context, p SearchParams, f func(feed *AtomFeed, cur Cursor) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	fetch := func(p SearchParams) pendingPage {
+		ch := make(chan pageResult, 1)
+		go func() {
+			start := time.Now()
+			feed, err := c.Query(ctx, p)
+			ch <- pageResult{feed, err, time.Since(start)}
+		}()
+		return pendingPage{p, ch}
+	}
+
+	next, skip := pagingParams(p)
+	first, limit := next.Start+skip, c.maxResults()
+	cur := Cursor{Params: next, Next: first, Total: -1}
+	total := -1
+	var latency time.Duration // 取得に要した最長の時間
+	var pending []pendingPage
+	for {
+		// 総件数が分かるまでは1ページずつ取得する
+		for len(pending) == 0 || (total >= 0 && len(pending) <= c.prefetch() && next.Start <= total &&
+			(limit <= 0 || next.Start-first < limit)) {
+			pending = append(pending, fetch(next))
+			next.Start += next.Count
+		}
+		page := pending[0]
+		pending = pending[1:]
+		var r pageResult
+		select {
+		case r = <-page.result:
+		default:
+			if deadline, ok := ctx.Deadline(); ok && total >= 0 && time.Until(deadline) < latency {
+				return ErrDeadlinePartial
+			}
+			r = <-page.result
+		}
+		if r.err != nil {
+			if total >= 0 && errors.Is(r.err, context.DeadlineExceeded) {
+				return fmt.Errorf("%w: %w", ErrDeadlinePartial, r.err)
+			}
+			return r.err
+		}
+		if r.elapsed > latency {
+			latency = r.elapsed
+		}
+		total = r.feed.TotalResults
+		last := len(r.feed.Entries) == 0 || page.params.Start-1+len(r.feed.Entries) >= total
+		// 読み飛ばす分とMaxResultsを超える分を除く
+		feed := r.feed
+		from, to := min(max(cur.Next-page.params.Start, 0), len(feed.Entries)), len(feed.Entries)
+		if limit > 0 && to-from > limit-(cur.Next-first) {
+			to, last = from+limit-(cur.Next-first), false
+		}
+		if from > 0 || to < len(feed.Entries) {
+			partial := *feed
+			partial.Entries = feed.Entries[from:to]
+			feed = &partial
+		}
+		cur.Next += len(feed.Entries)
+		cur.Total = total
+		if last {
+			cur.Next = max(cur.Next, total+1)
+		}
+		if err := f(feed, cur); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+		if limit > 0 && cur.Next-first >= limit {
+			return ErrMaxResults
+		}
+	}
+}
+
+// SearchAll はSearchParamsの条件でOpenSearchの検索結果をページ順にすべて取得し、エントリを連結して返すメソッド。
+// 件数の指定がない場合は1ページをMaxCount件とする。途中でエラーとなった場合はそれまでに取得したエントリとエラーを返す。
+// ctxの期限までにすべてのページを取得できない場合のエラーはErrDeadlinePartialとなる。
+// ClientのMaxResultsの件数で打ち切った場合は、その件数のエントリとErrMaxResultsを返す
+func (c *Client) SearchAll(ctx context.Context, p SearchParams) ([]Entry, error) {
+	var entries []Entry
+	err := c.eachPage(ctx, p, func(feed *AtomFeed, _ Cursor) error {
+		entries = append(entries, feed.Entries...)
+		return nil
+	})
+	return entries, err
+}
+
+// SearchEach はSearchParamsの条件でOpenSearchの検索結果をページ順に取得し、エントリごとにfを呼び出すメソッド。
+// 保持するのは取得中のページのみのため、大量の検索結果の書き出しに適する。fがエラーを返した場合はその時点で中止してエラーを返す
+func (c *Client) SearchEach(ctx context.Context, p SearchParams, f func(entry Entry) error) error {
+	return c.eachPage(ctx, p, func(feed *AtomFeed, _ Cursor) error {
+		for _, entry := range feed.Entries {
+			if err := f(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SearchIter はSearchParamsの条件でOpenSearchの検索結果を必要に応じてページ順に取得し、
+// エントリを1件ずつ返すイテレータを返すメソッド。検索でエラーとなった場合は最後にエラーを返して終了する
+//
+//	for entry, err := range client.SearchIter(ctx, params) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(entry.Title)
+//	}
+func (c *Client) SearchIter(ctx context.Context, p SearchParams) iter.Seq2[Entry, error] {
+	return func(yield func(Entry, error) bool) {
+		err := c.eachPage(ctx, p, func(feed *AtomFeed, _ Cursor) error {
+			for _, entry := range feed.Entries {
+				if !yield(entry, nil) {
+					return errStop
+				}
+			}
+			return nil
+		})
+		if err != nil && err != errStop {
+			yield(Entry{}, err)
+		}
+	}
+}
+
+// SearchStream はSearchParamsの条件でOpenSearchの検索結果をページ順に取得し、エントリをチャネルに送るメソッド。
+// エントリのチャネルはバッファを持たないため、受信側が読み出すまで次のページは取得しない。
+// 検索が終了するとエントリのチャネルを閉じ、エラーがあればエラーのチャネルに送ってから閉じる。
+// ctxが取り消された場合は送信を中止する
+func (c *Client) SearchStream(ctx context.Context, p SearchParams) (<-chan Entry, <-chan error) {
+	entries := make(chan Entry)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(entries)
+		err := c.eachPage(ctx, p, func(feed *AtomFeed, _ Cursor) error {
+			for _, entry := range feed.Entries {
+				select {
+				case entries <- entry:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			errc <- err
+		}
+	}()
+	return entries, errc
+}
@@ -0,0 +1,183 @@
+package cinii
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// searchServer はtotal件の検索結果をcountとpに従ってページごとに返すOpenSearchのハンドラ。
+// 各ページの応答をdelayだけ遅らせ、受け取ったクエリパラメタと同時に処理したリクエストの最大数を記録する
+type searchServer struct {
+	total int
+	delay time.Duration
+
+	mu          sync.Mutex
+	requests    []url.Values
+	inFlight    int
+	maxInFlight int
+}
+
+func (s *searchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	s.requests = append(s.requests, q)
+	s.inFlight++
+	s.maxInFlight = max(s.maxInFlight, s.inFlight)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(s.delay):
+	case <-r.Context().Done():
+		return
+	}
+	count, _ := strconv.Atoi(q.Get("count"))
+	page, err := strconv.Atoi(q.Get("p"))
+	if err != nil {
+		page = 1
+	}
+	start := (page-1)*count + 1
+	var entries []string
+	for i := start; i < start+count && i <= s.total; i++ {
+		entries = append(entries, fmt.Sprintf(`<entry><id>https://ci.nii.ac.jp/ncid/BA%08d</id></entry>`, i))
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=UTF-8")
+	fmt.Fprintf(w, `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/">`+
+		`<opensearch:totalResults>%d</opensearch:totalResults><opensearch:startIndex>%d</opensearch:startIndex>`+
+		`<opensearch:itemsPerPage>%d</opensearch:itemsPerPage>%s</feed>`, s.total, start, count, strings.Join(entries, ""))
+}
+
+// pages は受け取ったリクエストのページ番号を返すメソッド。pのないリクエストは1ページ目とする
+func (s *searchServer) pages() (ret []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, q := range s.requests {
+		page, err := strconv.Atoi(q.Get("p"))
+		if err != nil {
+			page = 1
+		}
+		ret = append(ret, page)
+	}
+	return
+}
+
+// checkEntries はエントリがfrom件目から連続するn件の検索結果かを検査する関数
+func checkEntries(t *testing.T, entries []Entry, from, n int) {
+	t.Helper()
+	if len(entries) != n {
+		t.Fatalf("len(entries) = %d, want %d", len(entries), n)
+	}
+	for i, e := range entries {
+		if want := fmt.Sprintf("BA%08d", from+i); !strings.HasSuffix(e.ID, want) {
+			t.Fatalf("entries[%d].ID = %q, want %s", i, e.ID, want)
+		}
+	}
+}
+
+func TestSearchAll(t *testing.T) {
+	srv := &searchServer{total: 45}
+	c := testServerClient(t, srv)
+
+	entries, err := c.SearchAll(context.Background(), SearchParams{Q: "猫", Count: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEntries(t, entries, 1, 45)
+	if got, want := srv.pages(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("requested pages = %v, want %v", got, want)
+	}
+}
+
+func TestSearchAllStartInPage(t *testing.T) {
+	srv := &searchServer{total: 45}
+	c := testServerClient(t, srv)
+
+	entries, err := c.SearchAll(context.Background(), SearchParams{Q: "猫", Count: 20, Start: 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEntries(t, entries, 25, 21)
+	if got, want := srv.pages(), []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("requested pages = %v, want %v", got, want)
+	}
+}
+
+func TestSearchAllPrefetch(t *testing.T) {
+	srv := &searchServer{total: 100, delay: 20 * time.Millisecond}
+	c := testServerClient(t, srv)
+	c.Prefetch = 3
+
+	entries, err := c.SearchAll(context.Background(), SearchParams{Q: "猫", Count: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEntries(t, entries, 1, 100)
+	if n := len(srv.pages()); n != 10 {
+		t.Errorf("server received %d requests, want 10", n)
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.maxInFlight < 2 || srv.maxInFlight > c.Prefetch+1 {
+		t.Errorf("max concurrent requests = %d, want 2 to %d", srv.maxInFlight, c.Prefetch+1)
+	}
+}
+
+func TestSearchAllMaxResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxResults int
+		want       int
+		err        error
+	}{
+		{"within a page", 25, 25, ErrMaxResults},
+		{"at a page boundary", 30, 30, ErrMaxResults},
+		{"equal to total", 100, 100, nil},
+		{"above total", 150, 100, nil},
+		{"unlimited", -1, 100, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &searchServer{total: 100}
+			c := testServerClient(t, srv)
+			c.MaxResults = tt.maxResults
+
+			entries, err := c.SearchAll(context.Background(), SearchParams{Q: "猫", Count: 10})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SearchAll() error = %v, want %v", err, tt.err)
+			}
+			checkEntries(t, entries, 1, tt.want)
+			if n, pages := len(srv.pages()), (tt.want+9)/10; n > pages {
+				t.Errorf("server received %d requests, want at most %d", n, pages)
+			}
+		})
+	}
+}
+
+func TestSearchAllDeadline(t *testing.T) {
+	srv := &searchServer{total: 100, delay: 30 * time.Millisecond}
+	c := testServerClient(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	entries, err := c.SearchAll(ctx, SearchParams{Q: "猫", Count: 10})
+	if !errors.Is(err, ErrDeadlinePartial) {
+		t.Fatalf("SearchAll() error = %v, want ErrDeadlinePartial", err)
+	}
+	if len(entries) == 0 || len(entries) == 100 || len(entries)%10 != 0 {
+		t.Fatalf("len(entries) = %d, want whole pages short of 100", len(entries))
+	}
+	checkEntries(t, entries, 1, len(entries))
+}
@@ -0,0 +1,268 @@
+package cinii
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OpenSearchの1ページの件数
+const (
+	DefaultCount = 20  // 既定の件数
+	MaxCount     = 200 // 最大の件数
+)
+
+// SortOrder はOpenSearchの検索結果の並び順（sortorder）
+type SortOrder int
+
+// 並び順の定数
+const (
+	ByRelevance    SortOrder = iota // 関連度順（sortorderを送信しない）
+	ByYearDesc                      // 出版年の新しい順
+	ByYearAsc                       // 出版年の古い順
+	ByTitleAsc                      // タイトルの読みの昇順
+	ByTitleDesc                     // タイトルの読みの降順
+	ByHoldingsDesc                  // 所蔵館数の多い順
+	ByHoldingsAsc                   // 所蔵館数の少ない順
+)
+
+var sortOrderNames = []string{"relevance", "year_desc", "year_asc", "title_asc", "title_desc", "holdings_desc", "holdings_asc"}
+
+// Stringerインターフェースの実装
+func (o SortOrder) String() string {
+	if o >= 0 && int(o) < len(sortOrderNames) {
+		return sortOrderNames[o]
+	}
+	return fmt.Sprintf("SortOrder(%d)", int(o))
+}
+
+// ParseSortOrder は"year_desc"等の並び順の名前からSortOrderを返す関数
+func ParseSortOrder(s string) (SortOrder, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range sortOrderNames {
+		if s == name {
+			return SortOrder(i), nil
+		}
+	}
+	return ByRelevance, fmt.Errorf("cinii: unknown sort order: %s", s)
+}
+
+// MaterialType は検索対象の資料の種類（type）
+type MaterialType int
+
+// 資料の種類の定数
+const (
+	MaterialAny    MaterialType = iota // 図書と雑誌
+	MaterialBook                       // 図書
+	MaterialSerial                     // 雑誌
+)
+
+// Format はOpenSearchのレスポンスの形式（format）
+type Format int
+
+// レスポンスの形式の定数
+const (
+	FormatAtom Format = iota // Atom（formatを送信しない）
+	FormatJSON               // JSON
+	FormatRSS                // RSS 2.0
+)
+
+var formatNames = []string{"atom", "json", "rss"}
+
+// Stringerインターフェースの実装
+func (f Format) String() string {
+	if f >= 0 && int(f) < len(formatNames) {
+		return formatNames[f]
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// SearchParams はOpenSearchの検索条件の構造体。ゼロ値の項目は送信しない
+type SearchParams struct {
+	Q              string       // フリーワード（q）
+	Title          string       // タイトル（title）
+	Author         string       // 著者名（author）
+	AuthorID       string       // 著者ID（authorid）
+	Publisher      string       // 出版者（publisher）
+	Subject        string       // 件名（subject）
+	Note           string       // 注記（note）
+	ISBN           string       // ISBN（isbn）
+	ISSN           string       // ISSN（issn）
+	NCID           string       // NCID（ncid）
+	Classification string       // 分類（clas）
+	GMD            string       // 資料種別（gmd）
+	Lang           string       // 本文の言語コード（lang）
+	LibraryID      string       // 所蔵館のFAID（fano）
+	Area           string       // 所蔵館の地域（area）。都道府県コード（"13"）、都道府県名、地方名（"関西"）
+	YearFrom       int          // 出版年の下限（year_from）
+	YearTo         int          // 出版年の上限（year_to）
+	Type           MaterialType // 資料の種類（type）
+
+	Count     int       // 1ページの件数（count）
+	Start     int       // 取得する最初の結果の番号（1から）。countごとのページ番号（p）に変換して送信する
+	SortOrder SortOrder // 並び順（sortorder）
+	Format    Format    // レスポンスの形式（format）。いずれの形式でもAtomFeedに変換して返す
+
+	AppID string // appid。空の場合はClientのAppIDを使う
+}
+
+// Values はSearchParamsをOpenSearchのクエリパラメタに変換するメソッド
+func (p SearchParams) Values() url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if len(value) > 0 {
+			q.Set(key, value)
+		}
+	}
+	setInt := func(key string, value int) {
+		if value > 0 {
+			q.Set(key, strconv.Itoa(value))
+		}
+	}
+	set("q", p.Q)
+	set("title", p.Title)
+	set("author", p.Author)
+	set("authorid", p.AuthorID)
+	set("publisher", p.Publisher)
+	set("subject", p.Subject)
+	set("note", p.Note)
+	set("isbn", p.ISBN)
+	set("issn", p.ISSN)
+	set("ncid", p.NCID)
+	set("clas", p.Classification)
+	set("gmd", p.GMD)
+	set("lang", p.Lang)
+	set("fano", strings.ToUpper(strings.TrimSpace(p.LibraryID)))
+	if codes, ok := areaCodes(p.Area); ok {
+		set("area", strings.Join(codes, ","))
+	}
+	setInt("year_from", p.YearFrom)
+	setInt("year_to", p.YearTo)
+	setInt("type", int(p.Type))
+	count := p.pageSize()
+	setInt("count", count)
+	if p.Start > 1 {
+		setInt("p", (p.Start-1)/count+1)
+	}
+	setInt("sortorder", int(p.SortOrder))
+	if p.Format != FormatAtom {
+		set("format", p.Format.String())
+	}
+	set("appid", p.AppID)
+	return q
+}
+
+// pageSize は1ページの件数を返すメソッド。指定がない場合はDefaultCountとする
+func (p SearchParams) pageSize() int {
+	if p.Count <= 0 {
+		return DefaultCount
+	}
+	return p.Count
+}
+
+// Clamp は1ページの件数を1からMaxCountの範囲に、取得する最初の結果の番号をページの先頭に丸めた
+// SearchParamsを返すメソッド。指定がない件数はDefaultCount、番号は1とする
+func (p SearchParams) Clamp() SearchParams {
+	switch {
+	case p.Count <= 0:
+		p.Count = DefaultCount
+	case p.Count > MaxCount:
+		p.Count = MaxCount
+	}
+	if p.Start < 1 {
+		p.Start = 1
+	}
+	p.Start -= (p.Start - 1) % p.Count
+	return p
+}
+
+// WithYears は出版年の範囲を設定したSearchParamsを返すメソッド
+func (p SearchParams) WithYears(y YearRange) SearchParams {
+	p.YearFrom, p.YearTo = y.From, y.To
+	return p
+}
+
+// Years は設定された出版年の範囲を返すメソッド。範囲の一方のみの場合は他方を0とする
+func (p SearchParams) Years() (YearRange, bool) {
+	return YearRange{p.YearFrom, p.YearTo}, p.YearFrom > 0 || p.YearTo > 0
+}
+
+// areaCodes は都道府県コード、都道府県名または地方名から2桁の都道府県コードの配列を返す関数
+func areaCodes(area string) ([]string, bool) {
+	area = strings.TrimSpace(area)
+	if len(area) == 0 {
+		return nil, false
+	}
+	if n, err := strconv.Atoi(area); err == nil {
+		if n < 1 || n > len(prefectures) {
+			return nil, false
+		}
+		return []string{fmt.Sprintf("%02d", n)}, true
+	}
+	prefs, ok := PrefecturesIn(area)
+	if !ok {
+		return nil, false
+	}
+	codes := make([]string, 0, len(prefs))
+	for _, pref := range prefs {
+		for i, p := range prefectures {
+			if p == pref {
+				codes = append(codes, fmt.Sprintf("%02d", i+1))
+			}
+		}
+	}
+	return codes, true
+}
+
+// validYear は4桁の西暦年かを返す関数
+func validYear(year int) bool {
+	return 1000 <= year && year <= 9999
+}
+
+// Validate は検索条件を検証するメソッド
+func (p SearchParams) Validate() error {
+	if p.YearFrom != 0 && !validYear(p.YearFrom) {
+		return fmt.Errorf("cinii: invalid year_from: %d", p.YearFrom)
+	}
+	if p.YearTo != 0 && !validYear(p.YearTo) {
+		return fmt.Errorf("cinii: invalid year_to: %d", p.YearTo)
+	}
+	if p.YearFrom != 0 && p.YearTo != 0 && p.YearFrom > p.YearTo {
+		return fmt.Errorf("cinii: year_from %d is after year_to %d", p.YearFrom, p.YearTo)
+	}
+	switch {
+	case p.Count < 0 || p.Count > MaxCount:
+		return fmt.Errorf("cinii: count must be between 1 and %d: %d", MaxCount, p.Count)
+	case p.Start < 0:
+		return fmt.Errorf("cinii: invalid start: %d", p.Start)
+	case p.Start > 1 && (p.Start-1)%p.pageSize() != 0:
+		return fmt.Errorf("cinii: start %d is not at a page boundary for count %d", p.Start, p.pageSize())
+	case p.SortOrder < ByRelevance || p.SortOrder > ByHoldingsAsc:
+		return fmt.Errorf("cinii: unknown sort order: %d", p.SortOrder)
+	case p.Format < FormatAtom || int(p.Format) >= len(formatNames):
+		return fmt.Errorf("cinii: unknown format: %d", p.Format)
+	case p.Type < MaterialAny || p.Type > MaterialSerial:
+		return fmt.Errorf("cinii: unknown material type: %d", p.Type)
+	case p.Type == MaterialSerial && len(p.ISBN) > 0:
+		return fmt.Errorf("cinii: isbn cannot be used for serials")
+	case p.Type == MaterialBook && len(p.ISSN) > 0:
+		return fmt.Errorf("cinii: issn cannot be used for books")
+	}
+	if faid := strings.ToUpper(strings.TrimSpace(p.LibraryID)); len(faid) > 0 && !faidPattern.MatchString(faid) {
+		return fmt.Errorf("cinii: invalid FAID: %q", p.LibraryID)
+	}
+	if _, ok := areaCodes(p.Area); len(strings.TrimSpace(p.Area)) > 0 && !ok {
+		return fmt.Errorf("cinii: unknown area: %s", p.Area)
+	}
+	return nil
+}
+
+// Query はSearchParamsの条件でCiNii BooksをOpenSearchで検索するメソッド。条件は送信前に検証する
+func (c *Client) Query(ctx context.Context, p SearchParams) (*AtomFeed, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return c.Search(ctx, p.Values())
+}
@@ -0,0 +1,90 @@
+package cinii
+
+import (
+	"fmt"
+	"strings"
+)
+
+// holdingsCount はレコードの所蔵館数を返すメソッド
+func (r *Record) holdingsCount() int {
+	if len(r.Descriptions) > 0 && r.Descriptions[0].OwnerCount > 0 {
+		return r.Descriptions[0].OwnerCount
+	}
+	holdings, _ := r.Holdings()
+	return len(holdings)
+}
+
+// Locale は人が読むための出力の言語
+type Locale int
+
+// 言語の定数
+const (
+	LocaleJa Locale = iota // 日本語
+	LocaleEn               // 英語
+)
+
+// Labels は目録カード形式の出力の項目名の構造体
+type Labels struct {
+	Authors    string
+	Publisher  string
+	Date       string
+	NCID       string
+	OwnerCount string
+}
+
+// localeLabels は言語ごとの項目名
+var localeLabels = map[Locale]Labels{
+	LocaleJa: {Authors: "著者", Publisher: "出版者", Date: "出版年", NCID: "NCID", OwnerCount: "所蔵館数"},
+	LocaleEn: {Authors: "Authors", Publisher: "Publisher", Date: "Date", NCID: "NCID", OwnerCount: "Holdings"},
+}
+
+// DefaultLocale はRecord.Stringで用いる言語
+var DefaultLocale = LocaleJa
+
+// LabelsFor は言語の項目名を返す関数。未知の言語の場合は日本語の項目名を返す
+func LabelsFor(locale Locale) Labels {
+	if labels, ok := localeLabels[locale]; ok {
+		return labels
+	}
+	return localeLabels[LocaleJa]
+}
+
+// Stringerインターフェースの実装。DefaultLocaleの項目名を用いて目録カード形式で書誌を出力する
+func (r *Record) String() string {
+	return r.Format(LabelsFor(DefaultLocale))
+}
+
+// Format は指定した項目名を用いて目録カード形式で書誌を出力するメソッド
+func (r *Record) Format(labels Labels) string {
+	if len(r.Descriptions) == 0 {
+		return ""
+	}
+	d := r.Descriptions[0]
+
+	var sb strings.Builder
+	if title, ok := r.TitleWithReading(); ok {
+		sb.WriteString(title.Title)
+		if len(title.Reading) > 0 {
+			fmt.Fprintf(&sb, " (%s)", title.Reading)
+		}
+		sb.WriteString("\n")
+	}
+	if authors, ok := r.Authors(); ok {
+		names := make([]string, len(authors))
+		for i, author := range authors {
+			names[i] = author[0]
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", labels.Authors, strings.Join(names, "; "))
+	}
+	if len(d.Publisher) > 0 {
+		fmt.Fprintf(&sb, "%s: %s\n", labels.Publisher, strings.Join(d.Publisher, "; "))
+	}
+	if len(d.Date) > 0 {
+		fmt.Fprintf(&sb, "%s: %s\n", labels.Date, d.Date)
+	}
+	if len(d.NCID) > 0 {
+		fmt.Fprintf(&sb, "%s: %s\n", labels.NCID, d.NCID)
+	}
+	fmt.Fprintf(&sb, "%s: %d\n", labels.OwnerCount, r.holdingsCount())
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+package cinii
+
+import "strings"
+
+// Publisher は出版地と出版者名の構造体
+type Publisher struct {
+	Place string
+	Name  string
+}
+
+// Stringerインターフェースの実装
+func (p Publisher) String() string {
+	if len(p.Place) == 0 {
+		return p.Name
+	}
+	return p.Place + " : " + p.Name
+}
+
+// ParsePublisher は"東京 : 岩波書店"形式の出版者文字列を出版地と出版者名に分割する関数
+func ParsePublisher(s string) Publisher {
+	s = strings.TrimSpace(s)
+	for _, sep := range []string{" : ", "：", ":"} {
+		if i := strings.Index(s, sep); i >= 0 {
+			return Publisher{
+				Place: strings.TrimSpace(s[:i]),
+				Name:  strings.TrimSpace(s[i+len(sep):]),
+			}
+		}
+	}
+	return Publisher{Name: s}
+}
+
+// Publishers はレコードから出版地と出版者名の配列を返すメソッド
+func (r *Record) Publishers() (ret []Publisher, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	fields := r.Descriptions[0].Publisher
+	if len(fields) == 0 {
+		return nil, false
+	}
+	ret = make([]Publisher, len(fields))
+	for i, field := range fields {
+		ret[i] = ParsePublisher(field)
+	}
+	return ret, true
+}
+
+// PublicationInfo は版、言語、出版年の構造体
+type PublicationInfo struct {
+	Edition  string
+	Language string
+	Date     string
+}
+
+// PublicationInfo はレコードから版、言語、出版年を返すメソッド
+func (r *Record) PublicationInfo() (info PublicationInfo, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return info, false
+	}
+	d := r.Descriptions[0]
+	info = PublicationInfo{
+		Edition:  strings.TrimSpace(d.Edition),
+		Language: strings.TrimSpace(d.Language),
+		Date:     strings.TrimSpace(d.Date),
+	}
+	return info, info != PublicationInfo{}
+}
+
+// PhysicalDescription は形態（ページ数等、大きさ、付属資料）の構造体
+type PhysicalDescription struct {
+	Extent       string   // ページ数等
+	Size         string   // 大きさ
+	Accompanying []string // 付属資料
+}
+
+// PhysicalDescription はレコードから形態を返すメソッド。
+// "324p ; 19cm + CD-ROM1枚"のような形態の記述は分割して返す
+func (r *Record) PhysicalDescription() (pd PhysicalDescription, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return pd, false
+	}
+	d := r.Descriptions[0]
+	extent := strings.TrimSpace(d.Extent)
+	if i := strings.Index(extent, " + "); i >= 0 {
+		pd.Accompanying = append(pd.Accompanying, strings.TrimSpace(extent[i+len(" + "):]))
+		extent = strings.TrimSpace(extent[:i])
+	}
+	if i := strings.Index(extent, " ; "); i >= 0 {
+		pd.Size = strings.TrimSpace(extent[i+len(" ; "):])
+		extent = strings.TrimSpace(extent[:i])
+	}
+	pd.Extent = extent
+	if size := strings.TrimSpace(d.Size); len(size) > 0 {
+		pd.Size = size
+	}
+	for _, a := range d.Accompanying {
+		if a = strings.TrimSpace(a); len(a) > 0 {
+			pd.Accompanying = append(pd.Accompanying, a)
+		}
+	}
+	ok = len(pd.Extent) > 0 || len(pd.Size) > 0 || len(pd.Accompanying) > 0
+	return pd, ok
+}
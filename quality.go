@@ -0,0 +1,77 @@
+package cinii
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// QualityIssue はデータ品質の検査項目の集計の構造体
+type QualityIssue struct {
+	Field string   `json:"field"`
+	Count int      `json:"count"`
+	NCIDs []string `json:"ncids"` // 問題のあるレコードのNCID
+}
+
+// QualityReport はレコードの集合に対するデータ品質の検査結果の構造体
+type QualityReport struct {
+	Total  int            `json:"total"`  // 検査したレコード数
+	Clean  int            `json:"clean"`  // 問題のなかったレコード数
+	Issues []QualityIssue `json:"issues"` // 検査項目ごとの集計。問題の多い順
+}
+
+// CheckQuality はレコードの配列をValidateで検査し、読みの欠落、出版者の欠落、所蔵館のないレコード、
+// 不正な出版年等の検査項目ごとに件数と該当するNCIDを集計する関数
+func CheckQuality(records []*Record) *QualityReport {
+	report := &QualityReport{Issues: []QualityIssue{}}
+	index := make(map[string]int)
+	for _, r := range records {
+		if r == nil {
+			continue
+		}
+		report.Total++
+		var ncid string
+		if len(r.Descriptions) > 0 {
+			ncid = r.Descriptions[0].NCID
+		}
+		findings := r.Validate()
+		if len(findings) == 0 {
+			report.Clean++
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, f := range findings {
+			if seen[f.Field] {
+				continue
+			}
+			seen[f.Field] = true
+			i, ok := index[f.Field]
+			if !ok {
+				i = len(report.Issues)
+				index[f.Field] = i
+				report.Issues = append(report.Issues, QualityIssue{Field: f.Field})
+			}
+			report.Issues[i].Count++
+			report.Issues[i].NCIDs = append(report.Issues[i].NCIDs, ncid)
+		}
+	}
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Count > report.Issues[j].Count
+	})
+	return report
+}
+
+// JSON は検査結果をJSONで返すメソッド
+func (q *QualityReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(q, "", "  ")
+}
+
+// Stringerインターフェースの実装。検査項目ごとの件数と割合を1行ずつ返す
+func (q *QualityReport) String() string {
+	lines := []string{fmt.Sprintf("total: %d, clean: %d", q.Total, q.Clean)}
+	for _, issue := range q.Issues {
+		lines = append(lines, fmt.Sprintf("%s: %d (%.1f%%)", issue.Field, issue.Count, 100*float64(issue.Count)/float64(q.Total)))
+	}
+	return strings.Join(lines, "\n")
+}
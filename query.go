@@ -0,0 +1,215 @@
+package cinii
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// QueryBuilder はSearchParamsを組み立てる構造体。メソッドはレシーバを返すため連結して呼び出せる
+type QueryBuilder struct {
+	params SearchParams
+	errs   []error
+}
+
+// NewQuery は空の検索条件のQueryBuilderを返す関数
+func NewQuery() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// setString は文字列の項目を設定するメソッド。異なる値が既に設定されている場合はエラーとする
+func (b *QueryBuilder) setString(name string, field *string, value string) *QueryBuilder {
+	value = strings.TrimSpace(value)
+	if len(*field) > 0 && *field != value {
+		b.errs = append(b.errs, fmt.Errorf("cinii: %s is already set: %q", name, *field))
+		return b
+	}
+	*field = value
+	return b
+}
+
+// Keyword はフリーワードを設定するメソッド
+func (b *QueryBuilder) Keyword(q string) *QueryBuilder {
+	return b.setString("keyword", &b.params.Q, q)
+}
+
+// Title はタイトルを設定するメソッド
+func (b *QueryBuilder) Title(title string) *QueryBuilder {
+	return b.setString("title", &b.params.Title, title)
+}
+
+// Author は著者名を設定するメソッド
+func (b *QueryBuilder) Author(author string) *QueryBuilder {
+	return b.setString("author", &b.params.Author, author)
+}
+
+// AuthorID は著者ID（DA00000001）を設定するメソッド
+func (b *QueryBuilder) AuthorID(id string) *QueryBuilder {
+	return b.setString("authorid", &b.params.AuthorID, id)
+}
+
+// Publisher は出版者を設定するメソッド
+func (b *QueryBuilder) Publisher(publisher string) *QueryBuilder {
+	return b.setString("publisher", &b.params.Publisher, publisher)
+}
+
+// Subject は件名を設定するメソッド
+func (b *QueryBuilder) Subject(subject string) *QueryBuilder {
+	return b.setString("subject", &b.params.Subject, subject)
+}
+
+// Note は注記を設定するメソッド
+func (b *QueryBuilder) Note(note string) *QueryBuilder {
+	return b.setString("note", &b.params.Note, note)
+}
+
+// ISBN はISBNを設定するメソッド
+func (b *QueryBuilder) ISBN(isbn string) *QueryBuilder {
+	return b.setString("isbn", &b.params.ISBN, isbn)
+}
+
+// ISSN はISSNを設定するメソッド
+func (b *QueryBuilder) ISSN(issn string) *QueryBuilder {
+	return b.setString("issn", &b.params.ISSN, issn)
+}
+
+// NCID はNCIDを設定するメソッド
+func (b *QueryBuilder) NCID(ncid string) *QueryBuilder {
+	return b.setString("ncid", &b.params.NCID, ncid)
+}
+
+// Classification は分類記号を設定するメソッド
+func (b *QueryBuilder) Classification(clas string) *QueryBuilder {
+	return b.setString("classification", &b.params.Classification, clas)
+}
+
+// GMD は資料種別を設定するメソッド
+func (b *QueryBuilder) GMD(gmd string) *QueryBuilder {
+	return b.setString("gmd", &b.params.GMD, gmd)
+}
+
+// Lang は本文の言語コード（"jpn"等）を設定するメソッド
+func (b *QueryBuilder) Lang(lang string) *QueryBuilder {
+	return b.setString("lang", &b.params.Lang, lang)
+}
+
+// LibraryID は所蔵館のFAIDを設定するメソッド
+func (b *QueryBuilder) LibraryID(faid string) *QueryBuilder {
+	return b.setString("library id", &b.params.LibraryID, faid)
+}
+
+// Area は所蔵館の地域を都道府県コード、都道府県名または地方名で設定するメソッド
+func (b *QueryBuilder) Area(area string) *QueryBuilder {
+	return b.setString("area", &b.params.Area, area)
+}
+
+// Material は検索対象を図書または雑誌に限定するメソッド
+func (b *QueryBuilder) Material(t MaterialType) *QueryBuilder {
+	b.params.Type = t
+	return b
+}
+
+// YearRange は出版年の範囲を設定するメソッド。0は範囲の端を指定しないことを表す
+func (b *QueryBuilder) YearRange(from, to int) *QueryBuilder {
+	b.params.YearFrom, b.params.YearTo = from, to
+	return b
+}
+
+// Years はParseYears等で得た出版年の範囲を設定するメソッド
+func (b *QueryBuilder) Years(y YearRange) *QueryBuilder {
+	return b.YearRange(y.From, y.To)
+}
+
+// Sort は並び順を設定するメソッド
+func (b *QueryBuilder) Sort(order SortOrder) *QueryBuilder {
+	b.params.SortOrder = order
+	return b
+}
+
+// Format はレスポンスの形式を設定するメソッド
+func (b *QueryBuilder) Format(f Format) *QueryBuilder {
+	b.params.Format = f
+	return b
+}
+
+// Count は1ページの件数を設定するメソッド
+func (b *QueryBuilder) Count(count int) *QueryBuilder {
+	b.params.Count = count
+	return b
+}
+
+// Start は取得する最初の結果の番号（1から）を設定するメソッド
+func (b *QueryBuilder) Start(start int) *QueryBuilder {
+	b.params.Start = start
+	return b
+}
+
+// AppID はappidを設定するメソッド
+func (b *QueryBuilder) AppID(appid string) *QueryBuilder {
+	return b.setString("appid", &b.params.AppID, appid)
+}
+
+// Build は組み立てたSearchParamsを返すメソッド。項目に異なる値を重ねて設定した場合、
+// ISBNとISSNのように同時に指定できない項目がある場合、SearchParams.Validateが失敗する場合はエラーを返す
+func (b *QueryBuilder) Build() (SearchParams, error) {
+	errs := append([]error(nil), b.errs...)
+	if len(b.params.ISBN) > 0 && len(b.params.ISSN) > 0 {
+		errs = append(errs, errors.New("cinii: isbn and issn are mutually exclusive"))
+	}
+	if err := b.params.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return b.params, errors.Join(errs...)
+	}
+	return b.params, nil
+}
+
+// freewordOperators はフリーワードで演算子とみなされる語
+var freewordOperators = map[string]bool{"AND": true, "OR": true, "NOT": true}
+
+// Phrase は語句を二重引用符で囲み、完全一致で検索するフリーワードを返す関数。
+// 語句中の二重引用符は区切りとみなされるため空白に置き換える
+func Phrase(s string) string {
+	s = strings.Join(strings.Fields(strings.NewReplacer(`"`, " ", `“`, " ", `”`, " ").Replace(s)), " ")
+	return `"` + s + `"`
+}
+
+// Term は1つの検索語をフリーワードの語として返す関数。
+// 空白を含む語、"-"で始まる語、演算子と同じ綴りの語は二重引用符で囲む
+func Term(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, " 　\"") || strings.HasPrefix(s, "-") || freewordOperators[strings.ToUpper(s)] {
+		return Phrase(s)
+	}
+	return s
+}
+
+// And は語または式をすべて含む（空白区切りの）フリーワードを返す関数
+func And(exprs ...string) string {
+	return joinExprs(exprs, " ")
+}
+
+// Or は語または式のいずれかを含む（"OR"区切りの）フリーワードを返す関数
+func Or(exprs ...string) string {
+	return joinExprs(exprs, " OR ")
+}
+
+// Not は語または式を含まない（"-"を前置した）フリーワードを返す関数
+func Not(expr string) string {
+	if expr = strings.TrimSpace(expr); len(expr) == 0 {
+		return ""
+	}
+	return "-" + expr
+}
+
+// joinExprs は空の式を除いてsepで連結する関数
+func joinExprs(exprs []string, sep string) string {
+	var parts []string
+	for _, e := range exprs {
+		if e = strings.TrimSpace(e); len(e) > 0 {
+			parts = append(parts, e)
+		}
+	}
+	return strings.Join(parts, sep)
+}
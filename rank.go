@@ -0,0 +1,94 @@
+package cinii
+
+import "sort"
+
+// RankCriteria は相互貸借の依頼先候補を順位付けする基準の構造体
+type RankCriteria struct {
+	Prefecture string   // 依頼館の都道府県。同じ都道府県、同じ地方の所蔵館を優先する
+	Consortium []string // 依頼館が参加するコンソーシアム等の所蔵館のFAID
+
+	// 各基準に該当する場合の点数
+	SamePrefecture int
+	SameRegion     int
+	Member         int
+	Types          map[InstitutionType]int // 機関種別ごとの点数
+
+	Limit int // 返す候補の最大数。0以下の場合はすべて
+}
+
+// DefaultRankCriteria は依頼館の都道府県とコンソーシアムのFAIDを受け取り、既定の点数を設定した基準を返す関数。
+// コンソーシアム、同じ都道府県、同じ地方の順に優先し、機関種別では大学を国の機関より優先する
+func DefaultRankCriteria(prefecture string, consortium []string) RankCriteria {
+	return RankCriteria{
+		Prefecture:     prefecture,
+		Consortium:     consortium,
+		SamePrefecture: 30,
+		SameRegion:     15,
+		Member:         50,
+		Types: map[InstitutionType]int{
+			InstitutionUniversity:    10,
+			InstitutionJuniorCollege: 5,
+			InstitutionResearch:      5,
+			InstitutionNational:      0,
+		},
+	}
+}
+
+// RankedHolding は点数と該当した基準を付加した所蔵の構造体
+type RankedHolding struct {
+	EnrichedHolding
+	Score   int
+	Reasons []string // 該当した基準（"consortium", "prefecture", "region", 機関種別）
+}
+
+// RankHoldings はEnrichHoldingsで取得した所蔵を基準に従って点数付けし、点数の高い順に返す関数。
+// 同点の場合は所蔵館名、FAIDの順とする
+func RankHoldings(holdings []EnrichedHolding, c RankCriteria) []RankedHolding {
+	members := make(map[string]bool, len(c.Consortium))
+	for _, faid := range c.Consortium {
+		members[faid] = true
+	}
+	prefecture := c.Prefecture
+	if prefs, ok := PrefecturesIn(prefecture); ok && len(prefs) == 1 {
+		prefecture = prefs[0]
+	}
+	region := RegionOf(prefecture)
+
+	ret := make([]RankedHolding, len(holdings))
+	for i, h := range holdings {
+		rh := RankedHolding{EnrichedHolding: h}
+		if members[h.FAID] {
+			rh.Score += c.Member
+			rh.Reasons = append(rh.Reasons, "consortium")
+		}
+		switch pref := h.Prefecture(); {
+		case len(pref) == 0:
+		case pref == prefecture:
+			rh.Score += c.SamePrefecture
+			rh.Reasons = append(rh.Reasons, "prefecture")
+		case len(region) > 0 && RegionOf(pref) == region:
+			rh.Score += c.SameRegion
+			rh.Reasons = append(rh.Reasons, "region")
+		}
+		t := h.Type()
+		if score, ok := c.Types[t]; ok {
+			rh.Score += score
+			rh.Reasons = append(rh.Reasons, t.String())
+		}
+		ret[i] = rh
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		if ret[i].Name != ret[j].Name {
+			return ret[i].Name < ret[j].Name
+		}
+		return ret[i].FAID < ret[j].FAID
+	})
+	if c.Limit > 0 && len(ret) > c.Limit {
+		ret = ret[:c.Limit]
+	}
+	return ret
+}
@@ -0,0 +1,345 @@
+package cinii
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// RDFで使用する名前空間URI
+const (
+	nsRDF     = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	nsRDFS    = "http://www.w3.org/2000/01/rdf-schema#"
+	nsDC      = "http://purl.org/dc/elements/1.1/"
+	nsDCTerms = "http://purl.org/dc/terms/"
+	nsFOAF    = "http://xmlns.com/foaf/0.1/"
+	nsCiNii   = "http://ci.nii.ac.jp/ns/1.0/"
+	nsPRISM   = "http://prismstandard.org/namespaces/basic/2.0/"
+	nsBIBO    = "http://purl.org/ontology/bibo/"
+	nsOWL     = "http://www.w3.org/2002/07/owl#"
+	nsXSD     = "http://www.w3.org/2001/XMLSchema#"
+)
+
+// namespaceAliases はcir.nii.ac.jpへの移行等で使われる名前空間URIの別名と正規の名前空間URIの対応
+var namespaceAliases = map[string]string{
+	"https://ci.nii.ac.jp/ns/1.0/":      nsCiNii,
+	"http://cir.nii.ac.jp/ns/1.0/":      nsCiNii,
+	"https://cir.nii.ac.jp/ns/1.0/":     nsCiNii,
+	"https://cir.nii.ac.jp/schema/1.0/": nsCiNii,
+	"https://xmlns.com/foaf/0.1/":       nsFOAF,
+	"https://purl.org/dc/elements/1.1/": nsDC,
+	"https://purl.org/dc/terms/":        nsDCTerms,
+	"https://purl.org/ontology/bibo/":   nsBIBO,
+}
+
+// canonicalNamespace は名前空間URIの別名を正規の名前空間URIに変換する関数
+func canonicalNamespace(ns string) string {
+	if canonical, ok := namespaceAliases[ns]; ok {
+		return canonical
+	}
+	return ns
+}
+
+// canonicalIRI はIRIの名前空間部分を正規の名前空間URIに変換する関数
+func canonicalIRI(iri string) string {
+	for alias, canonical := range namespaceAliases {
+		if strings.HasPrefix(iri, alias) {
+			return canonical + iri[len(alias):]
+		}
+	}
+	return iri
+}
+
+// nsNormalizer は要素と属性の名前空間URIの別名を正規化するxml.TokenReader
+type nsNormalizer struct {
+	d *xml.Decoder
+}
+
+// Token はxml.TokenReaderインターフェースの実装。
+// 属性はDecoder.Tokenが返したトークンの配列をそのまま書き換え、要素名に別名がなければトークンを作り直さない
+func (n nsNormalizer) Token() (xml.Token, error) {
+	tok, err := n.d.Token()
+	switch t := tok.(type) {
+	case xml.StartElement:
+		for i := range t.Attr {
+			if space := t.Attr[i].Name.Space; space != "xmlns" {
+				t.Attr[i].Name.Space = canonicalNamespace(space)
+			}
+		}
+		if space := canonicalNamespace(t.Name.Space); space != t.Name.Space {
+			t.Name.Space = space
+			return t, err
+		}
+	case xml.EndElement:
+		if space := canonicalNamespace(t.Name.Space); space != t.Name.Space {
+			t.Name.Space = space
+			return t, err
+		}
+	}
+	return tok, err
+}
+
+// defaultPrefixes は接頭辞の定義がない場合に使用する既定の接頭辞
+var defaultPrefixes = map[string]string{
+	"rdf":     nsRDF,
+	"rdfs":    nsRDFS,
+	"dc":      nsDC,
+	"dcterms": nsDCTerms,
+	"foaf":    nsFOAF,
+	"cinii":   nsCiNii,
+	"prism":   nsPRISM,
+	"bibo":    nsBIBO,
+	"owl":     nsOWL,
+	"xsd":     nsXSD,
+}
+
+// node はRDFノード構造体
+type node struct {
+	id    string
+	props map[string][]nodeValue
+	order []string
+}
+
+// nodeValue はnodeの目的語構造体
+type nodeValue struct {
+	IRI  string
+	Text string
+	Lang string
+	Node *node
+}
+
+func newNode(id string) *node {
+	return &node{id: id, props: make(map[string][]nodeValue)}
+}
+
+// add は述語と目的語を追加するメソッド
+func (n *node) add(pred string, v nodeValue) {
+	if _, ok := n.props[pred]; !ok {
+		n.order = append(n.order, pred)
+	}
+	n.props[pred] = append(n.props[pred], v)
+}
+
+// values は述語の目的語を返すメソッド。別名の名前空間URIで書かれた述語も正規の述語とみなす
+func (n *node) values(pred string) (ret []nodeValue) {
+	for _, p := range n.order {
+		if canonicalIRI(p) == pred {
+			ret = append(ret, n.props[p]...)
+		}
+	}
+	return
+}
+
+// text は述語の最初のリテラル値を返すメソッド
+func (n *node) text(pred string) string {
+	for _, v := range n.values(pred) {
+		if len(v.IRI) == 0 {
+			return v.Text
+		}
+	}
+	return ""
+}
+
+// nodeGraph はノードの集合
+type nodeGraph struct {
+	nodes []*node
+	index map[string]*node
+}
+
+func newNodeGraph() *nodeGraph {
+	return &nodeGraph{index: make(map[string]*node)}
+}
+
+// get はIDに対応するノードを返す。なければ作成して追加する
+func (g *nodeGraph) get(id string) *node {
+	if n, ok := g.index[id]; ok && len(id) > 0 {
+		return n
+	}
+	n := newNode(id)
+	g.nodes = append(g.nodes, n)
+	if len(id) > 0 {
+		g.index[id] = n
+	}
+	return n
+}
+
+// resolve は目的語が参照するノードを返す
+func (g *nodeGraph) resolve(v nodeValue) *node {
+	if v.Node != nil {
+		return v.Node
+	}
+	if n, ok := g.index[v.IRI]; ok && len(v.IRI) > 0 {
+		return n
+	}
+	return newNode(v.IRI)
+}
+
+// referenced は他のノードの目的語となっているノードのIDの集合を返すメソッド。自身への参照とrdf:typeは除く
+func (g *nodeGraph) referenced() map[string]bool {
+	ret := make(map[string]bool)
+	var walk func(n *node)
+	walk = func(n *node) {
+		for _, p := range n.order {
+			if p == nsRDF+"type" {
+				continue
+			}
+			for _, v := range n.props[p] {
+				if len(v.IRI) > 0 && v.IRI != n.id {
+					ret[v.IRI] = true
+				}
+				if v.Node != nil {
+					walk(v.Node)
+				}
+			}
+		}
+	}
+	for _, n := range g.nodes {
+		walk(n)
+	}
+	return ret
+}
+
+// record はノードの集合をRecord構造体に変換するメソッド。
+// 目的語として参照されるノードは参照元のDescriptionに含め、参照されないノードのみをDescriptionとする。
+// すべてのノードが参照されている（循環している）場合は全ノードをDescriptionとする
+func (g *nodeGraph) record(config *parseConfig) *Record {
+	record := &Record{}
+	referenced := g.referenced()
+	for _, n := range g.nodes {
+		if !referenced[n.id] {
+			record.Descriptions = append(record.Descriptions, g.description(n))
+		}
+	}
+	if len(record.Descriptions) == 0 {
+		for _, n := range g.nodes {
+			record.Descriptions = append(record.Descriptions, g.description(n))
+		}
+	}
+	if config.graph {
+		record.Graph = g.triples()
+	}
+	return record
+}
+
+// description はノードをDescription構造体に変換するメソッド
+func (g *nodeGraph) description(n *node) Description {
+	d := Description{AboutAttr: AboutAttr{n.id}}
+	for _, p := range n.order {
+		pred := canonicalIRI(p)
+		for _, v := range n.props[p] {
+			switch pred {
+			case nsRDF + "type":
+				d.Type.Resource = v.IRI
+			case nsFOAF + "isPrimaryTopicOf":
+				d.IsPrimaryTopicOf.Resource = v.IRI
+			case nsDC + "title":
+				d.Title = append(d.Title, TextField{Lang: v.Lang, Text: v.Text})
+			case nsDCTerms + "alternative":
+				d.Alternative = append(d.Alternative, TextField{Lang: v.Lang, Text: v.Text})
+			case nsDC + "creator":
+				d.Creator = v.Text
+			case nsDC + "publisher":
+				d.Publisher = append(d.Publisher, v.Text)
+			case nsDC + "language":
+				d.Language = v.Text
+			case nsDC + "date":
+				d.Date = v.Text
+			case nsFOAF + "topic":
+				d.Topics = append(d.Topics, g.resourceField(v))
+			case nsCiNii + "ncid":
+				d.NCID = v.Text
+			case nsPRISM + "edition":
+				d.Edition = v.Text
+			case nsDCTerms + "isPartOf":
+				d.IsPartOf = append(d.IsPartOf, g.resourceField(v))
+			case nsDCTerms + "hasPart":
+				d.HasPart = append(d.HasPart, g.resourceField(v))
+			case nsCiNii + "contentOfWorks":
+				d.ContentOfWorks = append(d.ContentOfWorks, v.Text)
+			case nsDCTerms + "medium":
+				d.Medium = TitleAttr{g.resolve(v).text(nsDC + "title")}
+			case nsCiNii + "ownerCount":
+				d.OwnerCount, _ = strconv.Atoi(strings.TrimSpace(v.Text))
+			case nsBIBO + "lccn":
+				d.LCCN = append(d.LCCN, v.Text)
+			case nsRDFS + "seeAlso":
+				d.SeeAlso = append(d.SeeAlso, ResourceAttr{v.IRI})
+			case nsFOAF + "maker":
+				d.Authors = append(d.Authors, Author{g.nameField(g.resolve(v))})
+			case nsBIBO + "owner":
+				d.Holdings = append(d.Holdings, Holding{g.nameField(g.resolve(v))})
+			case nsPRISM + "issn":
+				d.ISSN = append(d.ISSN, v.Text)
+			case nsDCTerms + "accrualPeriodicity":
+				d.Frequency = v.Text
+			case nsDC + "subject":
+				d.Subjects = append(d.Subjects, SubjectField{
+					ResourceAttr: ResourceAttr{v.IRI},
+					TitleAttr:    TitleAttr{g.resolve(v).text(nsDC + "title")},
+					Text:         v.Text,
+				})
+			case nsCiNii + "nbn":
+				d.NBN = append(d.NBN, v.Text)
+			case nsCiNii + "ndlCallNo":
+				d.NDLCallNumber = append(d.NDLCallNumber, v.Text)
+			case nsBIBO + "oclcnum":
+				d.OCLC = append(d.OCLC, v.Text)
+			case nsDC + "description":
+				d.GeneralNotes = append(d.GeneralNotes, v.Text)
+			case nsCiNii + "note":
+				d.Notes = append(d.Notes, v.Text)
+			case nsCiNii + "partInfo":
+				d.PartInfo = append(d.PartInfo, g.partInfo(v))
+			case nsDCTerms + "extent":
+				d.Extent = v.Text
+			case nsCiNii + "size":
+				d.Size = v.Text
+			case nsCiNii + "accompanyingMaterial":
+				d.Accompanying = append(d.Accompanying, v.Text)
+			case nsDCTerms + "replaces":
+				d.Replaces = append(d.Replaces, g.resourceField(v))
+			case nsDCTerms + "isReplacedBy":
+				d.IsReplacedBy = append(d.IsReplacedBy, g.resourceField(v))
+			case nsDCTerms + "identifier":
+				d.Identifier = append(d.Identifier, ValueField{ResourceAttr{v.IRI}, v.Text})
+			default:
+				if len(v.IRI) > 0 {
+					d.Extensions.add(pred, v.IRI)
+				} else {
+					d.Extensions.add(pred, v.Text)
+				}
+			}
+		}
+	}
+	return d
+}
+
+// resourceField は目的語をResourceField構造体に変換するメソッド
+func (g *nodeGraph) resourceField(v nodeValue) ResourceField {
+	return ResourceField{
+		ResourceAttr: ResourceAttr{v.IRI},
+		TitleAttr:    TitleAttr{g.resolve(v).text(nsDC + "title")},
+	}
+}
+
+// nameField はノードをNameField構造体に変換するメソッド
+func (g *nodeGraph) nameField(n *node) NameField {
+	field := NameField{AboutAttr: AboutAttr{n.id}}
+	for _, v := range n.values(nsFOAF + "name") {
+		field.Name = append(field.Name, TextField{Lang: v.Lang, Text: v.Text})
+	}
+	if sa := n.values(nsRDFS + "seeAlso"); len(sa) > 0 {
+		field.SeeAlso = ResourceAttr{sa[0].IRI}
+	}
+	return field
+}
+
+// partInfo は目的語をPartInfo構造体に変換するメソッド
+func (g *nodeGraph) partInfo(v nodeValue) PartInfo {
+	n := g.resolve(v)
+	info := PartInfo{ResourceAttr: ResourceAttr{v.IRI}, Volume: n.text(nsCiNii + "volume")}
+	for _, t := range n.values(nsDC + "title") {
+		info.Title = append(info.Title, TextField{Lang: t.Lang, Text: t.Text})
+	}
+	return info
+}
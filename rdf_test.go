@@ -0,0 +1,190 @@
+package cinii
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// recordParsers は同じ書誌を表す各形式のテストデータとパーサの組
+var recordParsers = []struct {
+	name  string
+	file  string
+	parse func([]byte, ...ParseOption) (*Record, error)
+}{
+	{"RDF/XML", "testdata/BA12345678.rdf", Parse},
+	{"Turtle", "testdata/BA12345678.ttl", ParseTurtle},
+	{"JSON-LD", "testdata/BA12345678.json", ParseJSON},
+}
+
+func parseTestRecord(t *testing.T, file string, parse func([]byte, ...ParseOption) (*Record, error), opts ...ParseOption) *Record {
+	t.Helper()
+	body, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := parse(body, opts...)
+	if err != nil {
+		t.Fatalf("parse %s: %v", file, err)
+	}
+	return record
+}
+
+func TestParseFormats(t *testing.T) {
+	wantAuthors := [][]string{{"夏目, 漱石", "ナツメ, ソウセキ", "DA00000001"}}
+	wantHoldings := [][]string{
+		{"東京大学 総合図書館", "FA000001", "https://opac.example.jp/1"},
+		{"京都大学 附属図書館", "FA000002", ""},
+		{"", "FA000003", "https://opac.example.jp/3"},
+	}
+
+	var base *Record
+	for _, tt := range recordParsers {
+		t.Run(tt.name, func(t *testing.T) {
+			r := parseTestRecord(t, tt.file, tt.parse)
+
+			if got, _ := r.TitleWithReading(); got != (TitleReading{Title: "吾輩は猫である", Reading: "ワガハイ ワ ネコ デアル"}) {
+				t.Errorf("TitleWithReading() = %+v", got)
+			}
+			if got, _ := r.Authors(); !reflect.DeepEqual(got, wantAuthors) {
+				t.Errorf("Authors() = %q, want %q", got, wantAuthors)
+			}
+			if got, _ := r.Holdings(); !reflect.DeepEqual(got, wantHoldings) {
+				t.Errorf("Holdings() = %q, want %q", got, wantHoldings)
+			}
+			if got, want := r.Descriptions[0].Medium.Title, "図書"; got != want {
+				t.Errorf("Medium = %q, want %q", got, want)
+			}
+			if got, want := r.Descriptions[0].OwnerCount, 3; got != want {
+				t.Errorf("OwnerCount = %d, want %d", got, want)
+			}
+
+			if got, want := len(r.Descriptions), 2; got != want {
+				t.Fatalf("len(Descriptions) = %d, want %d", got, want)
+			}
+			if base == nil {
+				base = r
+				return
+			}
+			if !reflect.DeepEqual(r.Descriptions, base.Descriptions) {
+				t.Errorf("Descriptions differ from %s:\n got %+v\nwant %+v", recordParsers[0].name, r.Descriptions, base.Descriptions)
+			}
+		})
+	}
+}
+
+// normalizeGraph はブランクノードの識別子を除いたトリプルを整列した文字列の配列で返す関数
+func normalizeGraph(g Graph) []string {
+	blank := func(s string) string {
+		if strings.HasPrefix(s, "_:") {
+			return "_:"
+		}
+		return s
+	}
+	ret := make([]string, len(g))
+	for i, t := range g {
+		ret[i] = strings.Join([]string{blank(t.Subject), t.Predicate, blank(t.Object), t.Lang}, " ")
+		if t.Literal {
+			ret[i] += " literal"
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func TestParseFormatsGraph(t *testing.T) {
+	var base []string
+	for _, tt := range recordParsers {
+		t.Run(tt.name, func(t *testing.T) {
+			r := parseTestRecord(t, tt.file, tt.parse, WithGraph())
+			if got := r.Graph.Objects("https://ci.nii.ac.jp/library/FA000002", nsFOAF+"name"); !reflect.DeepEqual(got, []string{"京都大学 附属図書館"}) {
+				t.Errorf("foaf:name of FA000002 = %q", got)
+			}
+
+			got := normalizeGraph(r.Graph)
+			if base == nil {
+				base = got
+				return
+			}
+			if !reflect.DeepEqual(got, base) {
+				t.Errorf("graph differs from %s:\n got %q\nwant %q", recordParsers[0].name, got, base)
+			}
+		})
+	}
+}
+
+func TestParseTurtleSyntax(t *testing.T) {
+	const s = "https://ci.nii.ac.jp/ncid/BA12345678"
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			"sparql prefix and base",
+			"BASE <https://ci.nii.ac.jp/ncid/>\nPREFIX dc: <http://purl.org/dc/elements/1.1/>\n<BA12345678> dc:title \"猫\" .",
+			[]string{s + " " + nsDC + "title 猫  literal"},
+		},
+		{
+			"escapes and long literal",
+			"<" + s + "> dc:title \"\\u732B\\t\\\"\" ; dc:description \"\"\"1行目\n2行目\"\"\" .",
+			[]string{
+				s + " " + nsDC + "description 1行目\n2行目  literal",
+				s + " " + nsDC + "title 猫\t\"  literal",
+			},
+		},
+		{
+			"typed literal and number",
+			"<" + s + "> cinii:ownerCount \"3\"^^xsd:integer, 4 .",
+			[]string{
+				s + " " + nsCiNii + "ownerCount 3  literal",
+				s + " " + nsCiNii + "ownerCount 4  literal",
+			},
+		},
+		{
+			"collection and repeated semicolons",
+			"<" + s + "> dc:publisher ( \"岩波書店\" \"新潮社\" ) ;; .",
+			[]string{
+				s + " " + nsDC + "publisher 岩波書店  literal",
+				s + " " + nsDC + "publisher 新潮社  literal",
+			},
+		},
+		{
+			"blank node subject",
+			"[ dc:title \"無題\"@ja ] .",
+			[]string{"_: " + nsDC + "title 無題 ja literal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseTurtle([]byte(tt.src), WithGraph())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := normalizeGraph(r.Graph); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTurtleError(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"undefined prefix", "<a> ex:p \"x\" ."},
+		{"unterminated literal", "<a> dc:title \"x ."},
+		{"unterminated IRI", "<a> dc:title <b ."},
+		{"missing dot", "<a> dc:title \"x\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTurtle([]byte(tt.src)); err == nil {
+				t.Errorf("ParseTurtle(%q) succeeded", tt.src)
+			}
+		})
+	}
+}
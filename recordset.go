@@ -0,0 +1,103 @@
+package cinii
+
+import (
+	"sort"
+	"strings"
+)
+
+// RecordSet はレコードの集合。集計のためのメソッドを持つ
+type RecordSet []*Record
+
+// Frequency は値と出現数の構造体
+type Frequency struct {
+	Value string
+	Count int
+}
+
+// sortFrequencies はmapの出現数を多い順、値の順に並べ替えて返す関数。nが正の場合は上位n件とする
+func sortFrequencies(counts map[string]int, n int) []Frequency {
+	ret := make([]Frequency, 0, len(counts))
+	for v, c := range counts {
+		ret = append(ret, Frequency{v, c})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Value < ret[j].Value
+	})
+	if n > 0 && len(ret) > n {
+		ret = ret[:n]
+	}
+	return ret
+}
+
+// each はnilでないレコードごとにfを呼び出すメソッド
+func (s RecordSet) each(f func(r *Record)) {
+	for _, r := range s {
+		if r != nil && len(r.Descriptions) > 0 {
+			f(r)
+		}
+	}
+}
+
+// TotalOwnerCount は所蔵館数の合計を返すメソッド
+func (s RecordSet) TotalOwnerCount() (total int) {
+	s.each(func(r *Record) {
+		total += r.holdingsCount()
+	})
+	return
+}
+
+// AverageOwnerCount はレコードあたりの所蔵館数の平均を返すメソッド。レコードがない場合は0
+func (s RecordSet) AverageOwnerCount() float64 {
+	var n int
+	s.each(func(*Record) { n++ })
+	if n == 0 {
+		return 0
+	}
+	return float64(s.TotalOwnerCount()) / float64(n)
+}
+
+// YearHistogram は出版年（範囲の場合は最初の年）ごとのレコード数を返すメソッド。出版年のないレコードは数えない
+func (s RecordSet) YearHistogram() map[int]int {
+	ret := make(map[int]int)
+	s.each(func(r *Record) {
+		if years, ok := r.PublicationYears(); ok {
+			ret[years.From]++
+		}
+	})
+	return ret
+}
+
+// PublisherFrequency は出版者名ごとのレコード数を多い順に返すメソッド。出版地は除いて数える
+func (s RecordSet) PublisherFrequency() []Frequency {
+	counts := make(map[string]int)
+	s.each(func(r *Record) {
+		publishers, _ := r.Publishers()
+		seen := make(map[string]bool)
+		for _, p := range publishers {
+			if name := strings.TrimSpace(p.Name); len(name) > 0 && !seen[name] {
+				seen[name] = true
+				counts[name]++
+			}
+		}
+	})
+	return sortFrequencies(counts, 0)
+}
+
+// TopTopics は件名ごとのレコード数を多い順にn件返すメソッド。nが0以下の場合はすべて返す
+func (s RecordSet) TopTopics(n int) []Frequency {
+	counts := make(map[string]int)
+	s.each(func(r *Record) {
+		topics, _ := r.Topics()
+		seen := make(map[string]bool)
+		for _, t := range topics {
+			if !seen[t] {
+				seen[t] = true
+				counts[t]++
+			}
+		}
+	})
+	return sortFrequencies(counts, n)
+}
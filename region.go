@@ -0,0 +1,105 @@
+package cinii
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// regions は地方名と都道府県の対応
+var regions = map[string][]string{
+	"北海道": {"北海道"},
+	"東北":  {"青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県"},
+	"関東":  {"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県"},
+	"中部":  {"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県", "岐阜県", "静岡県", "愛知県"},
+	"北陸":  {"新潟県", "富山県", "石川県", "福井県"},
+	"東海":  {"岐阜県", "静岡県", "愛知県", "三重県"},
+	"近畿":  {"三重県", "滋賀県", "京都府", "大阪府", "兵庫県", "奈良県", "和歌山県"},
+	"関西":  {"滋賀県", "京都府", "大阪府", "兵庫県", "奈良県", "和歌山県"},
+	"中国":  {"鳥取県", "島根県", "岡山県", "広島県", "山口県"},
+	"四国":  {"徳島県", "香川県", "愛媛県", "高知県"},
+	"九州":  {"福岡県", "佐賀県", "長崎県", "熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県"},
+	"沖縄":  {"沖縄県"},
+}
+
+// regionAliases は地方名の別名
+var regionAliases = map[string]string{
+	"hokkaido": "北海道",
+	"tohoku":   "東北",
+	"kanto":    "関東",
+	"chubu":    "中部",
+	"hokuriku": "北陸",
+	"tokai":    "東海",
+	"kinki":    "近畿",
+	"kansai":   "関西",
+	"chugoku":  "中国",
+	"shikoku":  "四国",
+	"kyushu":   "九州",
+	"okinawa":  "沖縄",
+	"近畿地方":     "近畿",
+	"関西地方":     "関西",
+	"関東地方":     "関東",
+	"東北地方":     "東北",
+	"中部地方":     "中部",
+	"中国地方":     "中国",
+	"四国地方":     "四国",
+	"九州地方":     "九州",
+}
+
+// trimPrefectureSuffix は都道府県名から"都", "府", "県"を除く関数
+func trimPrefectureSuffix(s string) string {
+	for _, suffix := range []string{"都", "府", "県"} {
+		if t := strings.TrimSuffix(s, suffix); t != s && len(t) > 0 {
+			return t
+		}
+	}
+	return s
+}
+
+// PrefecturesIn は地方名（"関西", "Kansai"等）または都道府県名（"京都", "京都府"等）を受け取り、該当する都道府県名の配列を返す関数
+func PrefecturesIn(region string) ([]string, bool) {
+	region = strings.TrimSpace(region)
+	if alias, ok := regionAliases[strings.ToLower(region)]; ok {
+		region = alias
+	}
+	if prefs, ok := regions[region]; ok {
+		return prefs, true
+	}
+	for _, pref := range prefectures {
+		if trimPrefectureSuffix(pref) == trimPrefectureSuffix(region) {
+			return []string{pref}, true
+		}
+	}
+	return nil, false
+}
+
+// HoldingsIn はEnrichHoldingsで取得した所蔵から、所蔵館が地方または都道府県に含まれる所蔵を返す関数。
+// 所蔵館の都道府県が不明な所蔵は含まない
+func HoldingsIn(holdings []EnrichedHolding, region string) (ret []EnrichedHolding) {
+	prefs, ok := PrefecturesIn(region)
+	if !ok {
+		return nil
+	}
+	in := make(map[string]bool, len(prefs))
+	for _, pref := range prefs {
+		in[pref] = true
+	}
+	for _, h := range holdings {
+		if h.Library != nil && in[h.Library.Prefecture] {
+			ret = append(ret, h)
+		}
+	}
+	return
+}
+
+// HoldingsIn はレコードの所蔵館の情報を取得し、所蔵館が地方または都道府県に含まれる所蔵を返すメソッド
+func (r *Record) HoldingsIn(ctx context.Context, client *Client, region string) ([]EnrichedHolding, error) {
+	if _, ok := PrefecturesIn(region); !ok {
+		return nil, fmt.Errorf("cinii: unknown region: %s", region)
+	}
+	holdings, err := r.EnrichHoldings(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return HoldingsIn(holdings, region), nil
+}
@@ -0,0 +1,83 @@
+package cinii
+
+import "context"
+
+// FetchParents はレコードの親書誌（シリーズ、セット等）を取得し、Record構造体のポインタの配列で返すメソッド。
+// clientがnilの場合はappidなしでパッケージのHTTPClientを使う。
+// 取得に失敗した場合はそれまでに取得したレコードとエラーを返す
+func (r *Record) FetchParents(ctx context.Context, client *Client) ([]*Record, error) {
+	parents, ok := r.Parents()
+	if !ok {
+		return nil, nil
+	}
+
+	var ret []*Record
+	seen := make(map[string]bool)
+	for _, parent := range parents {
+		ncid := parent[1]
+		if len(ncid) == 0 || seen[ncid] {
+			continue
+		}
+		seen[ncid] = true
+		record, err := client.Get(ctx, ncid)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, record)
+	}
+	return ret, nil
+}
+
+// FetchVolumes はレコードの巻（hasPart）のISBNをOpenSearchで検索し、各巻の書誌をRecord構造体のポインタの配列で返すメソッド。
+// clientのConcurrencyを上限に並行して取得する。検索結果がこのレコード自身または取得済みの書誌の場合は除く
+func (r *Record) FetchVolumes(ctx context.Context, client *Client) ([]*Record, error) {
+	ids, err := r.volumeNCIDs(ctx, client)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return client.fetchAll(ctx, ids)
+}
+
+// volumeNCIDs はレコードの巻（hasPart）のISBNをOpenSearchで検索し、各巻のNCIDを重複なく返すメソッド
+func (r *Record) volumeNCIDs(ctx context.Context, client *Client) ([]string, error) {
+	volumes, ok := r.Volumes()
+	if !ok {
+		return nil, nil
+	}
+
+	var isbns []string
+	for _, volume := range volumes {
+		if isbn, err := NewISBN(volume[1]); err == nil {
+			isbns = append(isbns, string(isbn))
+		}
+	}
+
+	// ISBNで検索してNCIDを求める
+	ncids := make([]string, len(isbns))
+	err := client.parallel(ctx, len(isbns), func(ctx context.Context, i int) error {
+		entry, err := client.findByISBN(ctx, isbns[i])
+		if err == ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if ncid, err := entry.NCID(); err == nil {
+			ncids[i] = string(ncid)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	seen := map[string]bool{r.Descriptions[0].NCID: true}
+	for _, ncid := range ncids {
+		if len(ncid) > 0 && !seen[ncid] {
+			seen[ncid] = true
+			ids = append(ids, ncid)
+		}
+	}
+	return ids, nil
+}
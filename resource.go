@@ -0,0 +1,123 @@
+package cinii
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ResourceType はCiNiiのリソース種別
+type ResourceType int
+
+// リソース種別の定数
+const (
+	ResourceUnknown  ResourceType = iota
+	ResourceBook                  // 図書・雑誌書誌 (/ncid)
+	ResourceAuthor                // 著者 (/author)
+	ResourceLibrary               // 所蔵館 (/library)
+	ResourceArticle               // 論文 (/naid)
+	ResourceResearch              // CiNii Research (/crid)
+)
+
+// resourcePaths はURLのパスとリソース種別の対応
+var resourcePaths = map[string]ResourceType{
+	"ncid":    ResourceBook,
+	"author":  ResourceAuthor,
+	"library": ResourceLibrary,
+	"naid":    ResourceArticle,
+	"crid":    ResourceResearch,
+}
+
+// Stringerインターフェースの実装
+func (t ResourceType) String() string {
+	for path, rt := range resourcePaths {
+		if rt == t {
+			return path
+		}
+	}
+	return "unknown"
+}
+
+// Resource はGetResourceが返すリソース構造体。
+// TypeがResourceBookの場合はRecordに、それ以外の場合はGraphに情報が入る
+type Resource struct {
+	Type   ResourceType
+	ID     string
+	URL    string
+	Record *Record
+	Graph  Graph
+}
+
+// resourceIDPattern はリソースIDとして許される文字列のパターン
+var resourceIDPattern = regexp.MustCompile(`^[0-9A-Za-z]+$`)
+
+// ParseResourceURL はCiNiiのリソースURLを受け取り、リソース種別とIDを返す関数
+func ParseResourceURL(s string) (ResourceType, string, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return ResourceUnknown, "", err
+	}
+	if host := u.Hostname(); host != "nii.ac.jp" && !strings.HasSuffix(host, ".nii.ac.jp") {
+		return ResourceUnknown, "", fmt.Errorf("cinii: not a CiNii URL: %s", s)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, segment := range segments {
+		if t, ok := resourcePaths[segment]; ok && i+1 < len(segments) {
+			id := trimExtension(segments[i+1])
+			if !resourceIDPattern.MatchString(id) {
+				return ResourceUnknown, "", fmt.Errorf("cinii: invalid resource ID: %q", id)
+			}
+			return t, id, nil
+		}
+	}
+	return ResourceUnknown, "", fmt.Errorf("cinii: unsupported CiNii URL: %s", s)
+}
+
+// resourceURL はリソースURLのスキームとホストを保ち、拡張子等を除いたURLを組み立てる関数
+func resourceURL(s string, t ResourceType, id string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", u.Scheme, u.Host, t, id), nil
+}
+
+// GetResource はCiNiiのリソースURLを受け取り、種別に応じて取得した情報をResource構造体のポインタで返す関数。
+// URLでない場合はNCIDとみなす
+func GetResource(s string, appid string) (*Resource, error) {
+	if !strings.Contains(s, "://") {
+		s = fmt.Sprintf("%s/%s", RetrieveEndopoint, s)
+	}
+	t, id, err := ParseResourceURL(s)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := resourceURL(s, t, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &Resource{Type: t, ID: id, URL: u}
+	body, err := fetch(u+".rdf", appid)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == ResourceBook {
+		resource.Record, err = Parse(body, WithGraph())
+		if err != nil {
+			return nil, err
+		}
+		resource.Graph = resource.Record.Graph
+		return resource, nil
+	}
+
+	resource.Graph, err = parseGraph(body)
+	if err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
@@ -1,11 +1,16 @@
 package cinii
 
 import (
+	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 )
 
 // RetrieveEndopoint は、RDF形式のCiNii Bookレコードを書誌IDで取得するためのURI
@@ -15,6 +20,8 @@ const RetrieveEndopoint = "http://ci.nii.ac.jp/ncid"
 type Record struct {
 	XMLName      xml.Name      `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# RDF"`
 	Descriptions []Description `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# Description"`
+	// Graph はWithGraphオプション指定時に全トリプルを保持する
+	Graph Graph `xml:"-"`
 }
 
 // Description はコンテナ構造体
@@ -23,7 +30,7 @@ type Description struct {
 	Type             ResourceAttr    `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# type"`
 	IsPrimaryTopicOf ResourceAttr    `xml:"http://xmlns.com/foaf/0.1/ isPrimaryTopicOf"`
 	Title            TextFields      `xml:"http://purl.org/dc/elements/1.1/ title"`
-	Alternative      []string        `xml:"http://purl.org/dc/terms/ alternative"`
+	Alternative      TextFields      `xml:"http://purl.org/dc/terms/ alternative"`
 	Creator          string          `xml:"http://purl.org/dc/elements/1.1/ creator"`
 	Publisher        []string        `xml:"http://purl.org/dc/elements/1.1/ publisher"`
 	Language         string          `xml:"http://purl.org/dc/elements/1.1/ language"`
@@ -36,10 +43,61 @@ type Description struct {
 	ContentOfWorks   []string        `xml:"http://ci.nii.ac.jp/ns/1.0/ contentOfWorks"`
 	Medium           TitleAttr       `xml:"http://purl.org/dc/terms/ medium"`
 	OwnerCount       int             `xml:"http://ci.nii.ac.jp/ns/1.0/ ownerCount"`
-	LCCN             []int           `xml:"http://purl.org/ontology/bibo/ lccn"`
+	LCCN             []string        `xml:"http://purl.org/ontology/bibo/ lccn"`
 	SeeAlso          []ResourceAttr  `xml:"http://www.w3.org/2000/01/rdf-schema# seeAlso"`
 	Authors          []Author        `xml:"http://xmlns.com/foaf/0.1/ maker"`
 	Holdings         []Holding       `xml:"http://purl.org/ontology/bibo/ owner"`
+	ISSN             []string        `xml:"http://prismstandard.org/namespaces/basic/2.0/ issn"`
+	Frequency        string          `xml:"http://purl.org/dc/terms/ accrualPeriodicity"`
+	Replaces         []ResourceField `xml:"http://purl.org/dc/terms/ replaces"`
+	IsReplacedBy     []ResourceField `xml:"http://purl.org/dc/terms/ isReplacedBy"`
+	Identifier       []ValueField    `xml:"http://purl.org/dc/terms/ identifier"`
+	Subjects         []SubjectField  `xml:"http://purl.org/dc/elements/1.1/ subject"`
+	NBN              []string        `xml:"http://ci.nii.ac.jp/ns/1.0/ nbn"`
+	NDLCallNumber    []string        `xml:"http://ci.nii.ac.jp/ns/1.0/ ndlCallNo"`
+	OCLC             []string        `xml:"http://purl.org/ontology/bibo/ oclcnum"`
+	GeneralNotes     []string        `xml:"http://purl.org/dc/elements/1.1/ description"`
+	Notes            []string        `xml:"http://ci.nii.ac.jp/ns/1.0/ note"`
+	PartInfo         []PartInfo      `xml:"http://ci.nii.ac.jp/ns/1.0/ partInfo"`
+	Extent           string          `xml:"http://purl.org/dc/terms/ extent"`
+	Size             string          `xml:"http://ci.nii.ac.jp/ns/1.0/ size"`
+	Accompanying     []string        `xml:"http://ci.nii.ac.jp/ns/1.0/ accompanyingMaterial"`
+	Extensions       Extensions      `xml:",any"`
+}
+
+// Extensions は構造体にマップされない要素の値を「名前空間URI+要素名」をキーに保持するマップ
+type Extensions map[string][]string
+
+// UnmarshalXML はxml.Unmarshalerインターフェースの実装
+func (e *Extensions) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		ResourceAttr
+		Text string `xml:",chardata"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	value := v.Resource
+	if len(value) == 0 {
+		value = strings.TrimSpace(v.Text)
+	}
+	e.add(start.Name.Space+start.Name.Local, value)
+	return nil
+}
+
+func (e *Extensions) add(name, value string) {
+	if *e == nil {
+		*e = make(Extensions)
+	}
+	(*e)[name] = append((*e)[name], value)
+}
+
+// Value はnameの最初の値を返すメソッド
+func (e Extensions) Value(name string) (string, bool) {
+	if values := e[name]; len(values) > 0 {
+		return values[0], true
+	}
+	return "", false
 }
 
 // AboutAttr はabout sttribute構造体
@@ -57,6 +115,20 @@ type TitleAttr struct {
 	Title string `xml:"http://purl.org/dc/elements/1.1/ title,attr"`
 }
 
+// ValueField はresource属性またはテキストで値を表す構造体
+type ValueField struct {
+	ResourceAttr
+	Text string `xml:",chardata"`
+}
+
+// Value はresource属性があればその値を、なければテキストを返すメソッド
+func (v ValueField) Value() string {
+	if len(v.Resource) > 0 {
+		return v.Resource
+	}
+	return strings.TrimSpace(v.Text)
+}
+
 // ResourceField はresource構造体
 type ResourceField struct {
 	ResourceAttr
@@ -72,22 +144,30 @@ type NameField struct {
 
 // Stringerインターフェースの実装
 func (n NameField) String() string {
-	str := n.Name[0].Text
-	if len(n.Name) > 1 {
-		str += fmt.Sprintf(" (%s)", n.Name[1].Text)
-	}
+	var sb strings.Builder
+	sb.WriteString(n.Name.String())
 	if about := n.About; len(about) > 0 {
-		about = strings.Replace(about, "http://ci.nii.ac.jp/author/", "", 1)
-		about = strings.Replace(about, "http://ci.nii.ac.jp/library/", "", 1)
-		about = strings.Replace(about, "#entity", "", 1)
-		str += fmt.Sprintf(" [%s]", about)
+		sb.WriteString(" [")
+		sb.WriteString(trimResourceURI(about))
+		sb.WriteString("]")
 	}
 	/* 所蔵館におけるこの書誌のURI
 	if sa := n.SeeAlso.Resource; len(sa) > 0 {
 		str += fmt.Sprintf(" -> %s", sa)
 	}
 	*/
-	return str
+	return sb.String()
+}
+
+// trimResourceURI はci.nii.ac.jp、cir.nii.ac.jpのリソースURIからIDのみを返す関数
+func trimResourceURI(uri string) string {
+	if i := strings.Index(uri, "#"); i >= 0 {
+		uri = uri[:i]
+	}
+	if strings.Contains(uri, "://") {
+		uri = uri[strings.LastIndex(uri, "/")+1:]
+	}
+	return uri
 }
 
 // TextField はよみを持つテキストフィールドの構造体
@@ -111,24 +191,26 @@ type TextFields []TextField
 
 // Stringerインターフェースの実装
 func (t TextFields) String() string {
-	str := t[0].Text
-	if len(t) > 1 {
-		str += fmt.Sprintf(" (%s)", t[1].Text)
+	switch len(t) {
+	case 0:
+		return ""
+	case 1:
+		return t[0].Text
 	}
-	return str
+	return t[0].Text + " (" + t[1].Text + ")"
 }
 
-// Title はレコードから[タイトル, 読み]を返すメソッド
-func (r *Record) Title() (ret []string) {
-	ret = make([]string, 2)
-	for _, title := range r.Descriptions[0].Title {
-		if len(title.Lang) > 0 {
-			ret[1] = title.Text
-		} else {
-			ret[0] = title.Text
+// primary は言語指定のない最初の値を返すメソッド。ない場合は最初の値、値がない場合は空とする
+func (t TextFields) primary() string {
+	for _, field := range t {
+		if len(field.Lang) == 0 {
+			return field.Text
 		}
 	}
-	return
+	if len(t) > 0 {
+		return t[0].Text
+	}
+	return ""
 }
 
 // Parents はレコードから[親書誌タイトル, NCID]の配列を返すメソッド
@@ -138,11 +220,11 @@ func (r *Record) Parents() (ret [][]string, ok bool) {
 		return nil, false
 	}
 	ret = make([][]string, len(fields))
+	// 要素ごとの割り当てを避けるため1つの配列を分割して使う
+	flat := make([]string, 2*len(fields))
 	for i, field := range fields {
-		id := field.Resource
-		id = strings.Replace(id, "http://ci.nii.ac.jp/ncid/", "", 1)
-		id = strings.Replace(id, "#entity", "", 1)
-		ret[i] = []string{field.Title, id}
+		ret[i] = flat[2*i : 2*i+2 : 2*i+2]
+		ret[i][0], ret[i][1] = field.Title, trimResourceURI(field.Resource)
 	}
 	return ret, true
 }
@@ -154,16 +236,19 @@ func (r *Record) Volumes() (ret [][]string, ok bool) {
 		return nil, false
 	}
 	ret = make([][]string, len(fields))
+	flat := make([]string, 2*len(fields))
 	for i, field := range fields {
-		id := field.Resource
-		id = strings.Replace(id, "urn:isbn:", "", 1)
-		ret[i] = []string{field.Title, id}
+		ret[i] = flat[2*i : 2*i+2 : 2*i+2]
+		ret[i][0], ret[i][1] = field.Title, strings.TrimPrefix(field.Resource, "urn:isbn:")
 	}
 	return ret, true
 }
 
 // Topics はレコードからTopicの配列を返すメソッド
 func (r *Record) Topics() (ret []string, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
 	fields := r.Descriptions[0].Topics
 	if len(fields) == 0 {
 		return nil, false
@@ -175,6 +260,35 @@ func (r *Record) Topics() (ret []string, ok bool) {
 	return ret, true
 }
 
+// Notes はレコードから注記（dc:description, cinii:note）の配列を返すメソッド
+func (r *Record) Notes() (ret []string, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	d := r.Descriptions[0]
+	for _, fields := range [][]string{d.GeneralNotes, d.Notes} {
+		for _, note := range fields {
+			if note = strings.TrimSpace(note); len(note) > 0 {
+				ret = append(ret, note)
+			}
+		}
+	}
+	return ret, len(ret) > 0
+}
+
+// LCCNs はレコードからLCCNの配列を返すメソッド
+func (r *Record) LCCNs() (ret []string, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	for _, lccn := range r.Descriptions[0].LCCN {
+		if lccn = strings.TrimSpace(lccn); len(lccn) > 0 {
+			ret = append(ret, lccn)
+		}
+	}
+	return ret, len(ret) > 0
+}
+
 // Authors はレコードから[著者名, 読み, ALID]の配列を返すメソッド
 func (r *Record) Authors() (ret [][]string, ok bool) {
 	// 書誌情報だけで著者情報はなし
@@ -196,10 +310,10 @@ func (r *Record) Authors() (ret [][]string, ok bool) {
 	}
 
 	ret = make([][]string, len(fields))
+	flat := make([]string, 3*len(fields))
 	for i, field := range fields {
 		id := field.Author.About
-		id = strings.Replace(id, "http://ci.nii.ac.jp/author/", "", 1)
-		id = strings.Replace(id, "#entity", "", 1)
+		id = trimResourceURI(id)
 
 		var author, yomi string
 		for _, name := range field.Author.Name {
@@ -209,7 +323,8 @@ func (r *Record) Authors() (ret [][]string, ok bool) {
 				author = name.Text
 			}
 		}
-		ret[i] = []string{author, yomi, id}
+		ret[i] = flat[3*i : 3*i+3 : 3*i+3]
+		ret[i][0], ret[i][1], ret[i][2] = author, yomi, id
 	}
 	return ret, true
 }
@@ -235,55 +350,286 @@ func (r *Record) Holdings() (ret [][]string, ok bool) {
 	}
 
 	ret = make([][]string, len(fields))
+	flat := make([]string, 3*len(fields))
 	for i, field := range fields {
 		holding := field.Holding
-		id := holding.About
-		id = strings.Replace(id, "http://ci.nii.ac.jp/library/", "", 1)
-		ret[i] = []string{holding.Name[0].Text, id, holding.SeeAlso.Resource}
+		ret[i] = flat[3*i : 3*i+3 : 3*i+3]
+		ret[i][0], ret[i][1], ret[i][2] = holding.Name.primary(), trimResourceURI(holding.About), holding.SeeAlso.Resource
 	}
 	return ret, true
 }
 
 // Get はレコードIDを受け取り、情報をRecord構造体のポインタで返す関数
-func Get(url string, appid string) (*Record, error) {
-	if !strings.HasPrefix(url, RetrieveEndopoint) {
-		url = fmt.Sprintf("%s/%s", RetrieveEndopoint, url)
+func Get(url string, appid string, opts ...ParseOption) (*Record, error) {
+	body, err := openRecord(url, ".rdf", appid)
+	if err != nil {
+		return nil, err
 	}
-	if !strings.HasSuffix(url, ".rdf") {
-		url += ".rdf"
+	defer body.Close()
+
+	record, err := decodeRecord(body, newParseConfig(opts))
+	if err != nil {
+		return nil, err
 	}
 
+	return record, nil
+}
+
+// openRecord はNCIDまたは書誌のURLと拡張子を受け取り、取得したデータを読み出すio.ReadCloserを返す関数
+func openRecord(id string, ext string, appid string) (io.ReadCloser, error) {
+	u, err := recordURL(id)
+	if err != nil {
+		return nil, err
+	}
+	return open(u+ext, appid)
+}
+
+// recordURL はNCIDまたは書誌のURLを検証し、拡張子を除いた書誌のURLを返す関数
+func recordURL(s string) (string, error) {
+	if !strings.Contains(s, "://") {
+		ncid, err := NewNCID(s)
+		if err != nil {
+			return "", err
+		}
+		return ncid.URL(), nil
+	}
+
+	t, id, err := ParseResourceURL(s)
+	if err != nil {
+		return "", err
+	}
+	if t != ResourceBook {
+		return "", fmt.Errorf("cinii: not a NCID URL: %s", s)
+	}
+	ncid, err := NewNCID(id)
+	if err != nil {
+		return "", err
+	}
+	return resourceURL(s, t, string(ncid))
+}
+
+// fetch はURLにappidを付加し、取得したデータをbyte[]で返す関数
+func fetch(u string, appid string) ([]byte, error) {
+	body, err := open(u, appid)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return ioutil.ReadAll(body)
+}
+
+// open はURLにappidを付加し、取得したデータをUTF-8で読み出すio.ReadCloserを返す関数
+func open(u string, appid string) (io.ReadCloser, error) {
+	return openContext(context.Background(), HTTPClient, u, appid)
+}
+
+// openContext はコンテキストとhttp.Clientを指定してURLにappidを付加し、取得したデータをUTF-8で読み出すio.ReadCloserを返す関数。
+// ステータスコードが2xxでない場合は*StatusErrorを返す
+func openContext(ctx context.Context, client *http.Client, u string, appid string) (io.ReadCloser, error) {
 	if len(appid) > 0 {
-		url = fmt.Sprintf("%s?appid=%s", url, appid)
+		u = fmt.Sprintf("%s?appid=%s", u, url.QueryEscape(appid))
 	}
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	// 旧URLからリダイレクトされた場合も同じ形式で取得できるようAcceptを指定する
+	req.Header.Set("Accept", acceptFor(u))
 
-	body, err := ioutil.ReadAll(resp.Body)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	body := drainCloser{resp.Body}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body.Close()
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, URL: stripQuery(u)}
+	}
 
-	record, err := Parse(body)
+	r, err := utf8Reader(body, resp.Header.Get("Content-Type"))
 	if err != nil {
+		body.Close()
 		return nil, err
 	}
+	return readCloser{r, body}, nil
+}
 
-	return record, nil
+// StatusError はHTTPのレスポンスのステータスコードが2xxでない場合のエラー
+type StatusError struct {
+	StatusCode int
+	Status     string // "404 Not Found"等
+	URL        string // appid等を含まないよう、クエリを除いたURL
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("cinii: unexpected HTTP status %s: %s", e.Status, e.URL)
+}
+
+// stripQuery はURLからクエリを除く関数
+func stripQuery(u string) string {
+	if i := strings.Index(u, "?"); i >= 0 {
+		return u[:i]
+	}
+	return u
+}
+
+// readCloser はio.Readerと元のio.Closerを組み合わせる構造体
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// acceptFor はURLの拡張子に対応するAcceptヘッダの値を返す関数
+func acceptFor(u string) string {
+	var query string
+	if i := strings.Index(u, "?"); i >= 0 {
+		u, query = u[:i], u[i+1:]
+	}
+	switch {
+	case strings.HasSuffix(u, ".rdf"):
+		return "application/rdf+xml"
+	case strings.HasSuffix(u, ".json"):
+		return "application/ld+json, application/json"
+	case strings.HasSuffix(u, ".ttl"):
+		return "text/turtle"
+	case strings.HasSuffix(u, "/opensearch/search"):
+		q, _ := url.ParseQuery(query)
+		switch q.Get("format") {
+		case FormatJSON.String():
+			return "application/json"
+		case FormatRSS.String():
+			return "application/rss+xml"
+		}
+		return "application/atom+xml"
+	}
+	return "*/*"
+}
+
+// trimExtension はURLからフラグメントと拡張子を除く関数
+func trimExtension(url string) string {
+	if i := strings.Index(url, "#"); i >= 0 {
+		url = url[:i]
+	}
+	for _, ext := range []string{".rdf", ".json", ".ttl", ".html"} {
+		url = strings.TrimSuffix(url, ext)
+	}
+	return url
+}
+
+// ParseOption はParseの動作を指定するオプション
+type ParseOption func(*parseConfig)
+
+// parseConfig はParseOptionで指定された設定
+type parseConfig struct {
+	graph      bool
+	strict     bool
+	headerOnly bool
+}
+
+func newParseConfig(opts []ParseOption) *parseConfig {
+	c := &parseConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithGraph は構造体にマップされない述語も含め、全トリプルをRecord.Graphに保持するオプション
+func WithGraph() ParseOption {
+	return func(c *parseConfig) {
+		c.graph = true
+	}
+}
+
+// HeaderOnly は最初のDescriptionのみを読み込み、所蔵館情報に達した時点でタイトル、NCID、出版者が
+// 揃っていれば読み込みを打ち切るオプション。WithGraphオプションは無視される
+func HeaderOnly() ParseOption {
+	return func(c *parseConfig) {
+		c.headerOnly = true
+	}
+}
+
+// Strict は必須要素（タイトル、NCID）が欠けている場合にエラーを返すオプション
+func Strict() ParseOption {
+	return func(c *parseConfig) {
+		c.strict = true
+	}
+}
+
+// MissingFieldError はStrictオプション指定時に必須要素が欠けている場合のエラー
+type MissingFieldError struct {
+	Fields []string
+}
+
+func (e *MissingFieldError) Error() string {
+	return fmt.Sprintf("cinii: missing mandatory fields: %s", strings.Join(e.Fields, ", "))
+}
+
+// check はStrictオプション指定時にRecordの必須要素を検査するメソッド
+func (c *parseConfig) check(record *Record) error {
+	if !c.strict {
+		return nil
+	}
+	if len(record.Descriptions) == 0 {
+		return &MissingFieldError{Fields: []string{"Description"}}
+	}
+
+	var missing []string
+	d := record.Descriptions[0]
+	if len(d.Title) == 0 || len(strings.TrimSpace(d.Title[0].Text)) == 0 {
+		missing = append(missing, "Title")
+	}
+	if len(strings.TrimSpace(d.NCID)) == 0 {
+		missing = append(missing, "NCID")
+	}
+	if len(missing) > 0 {
+		return &MissingFieldError{Fields: missing}
+	}
+	return nil
 }
 
 // Parse はRecord情報を含むbyte[]を受け取りRecord構造体のポインタで返す関数
-func Parse(body []byte) (*Record, error) {
-	// 取得したデータをXMLデコード
+func Parse(body []byte, opts ...ParseOption) (*Record, error) {
+	return decodeRecord(bytes.NewReader(body), newParseConfig(opts))
+}
+
+// bufferPool はdecodeRecordで使うバッファのプール
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// decodeRecord はRecord情報を読み出すio.Readerを受け取り、逐次デコードしたRecord構造体のポインタで返す関数
+func decodeRecord(r io.Reader, config *parseConfig) (*Record, error) {
+	// WithGraphオプション指定時はトリプルの抽出用に読み込んだデータを保持する
+	var raw *bytes.Buffer
+	if config.graph && !config.headerOnly {
+		raw = bufferPool.Get().(*bytes.Buffer)
+		raw.Reset()
+		defer bufferPool.Put(raw)
+		r = io.TeeReader(r, raw)
+	}
+
 	record := &Record{}
-	err := xml.Unmarshal(body, record)
+	d := newXMLDecoder(r)
+	if config.headerOnly {
+		d = xml.NewTokenDecoder(&headerFilter{d: d, seen: make(map[string]bool)})
+	}
+	err := d.Decode(record)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := config.check(record); err != nil {
+		return nil, err
+	}
+
+	if config.graph && !config.headerOnly {
+		if record.Graph, err = parseGraph(raw.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
 	return record, nil
 }
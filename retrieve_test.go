@@ -0,0 +1,51 @@
+package cinii
+
+import (
+	"os"
+	"testing"
+)
+
+func BenchmarkParse(b *testing.B) {
+	body, err := os.ReadFile("testdata/BA12345678.rdf")
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarks := []struct {
+		name string
+		opts []ParseOption
+	}{
+		{"Record", nil},
+		{"WithGraph", []ParseOption{WithGraph()}},
+		{"HeaderOnly", []ParseOption{HeaderOnly()}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(body)))
+			for i := 0; i < b.N; i++ {
+				if _, err := Parse(body, bm.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAccessors(b *testing.B) {
+	body, err := os.ReadFile("testdata/BA12345678.rdf")
+	if err != nil {
+		b.Fatal(err)
+	}
+	r, err := Parse(body)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Parents()
+		r.Volumes()
+		r.Authors()
+		r.Holdings()
+		_ = r.Descriptions[1].Holdings[0].Holding.String()
+	}
+}
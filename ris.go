@@ -0,0 +1,104 @@
+package cinii
+
+import (
+	"fmt"
+	"strings"
+)
+
+// risWriter はRISのタグ付き行を組み立てる構造体
+type risWriter struct {
+	sb strings.Builder
+}
+
+// add は値が空でなければ"TAG  - 値"の行を追加するメソッド
+func (w *risWriter) add(tag, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if len(value) > 0 {
+		fmt.Fprintf(&w.sb, "%s  - %s\r\n", tag, value)
+	}
+}
+
+// end はレコードの終わりの"ER  - "の行を追加するメソッド
+func (w *risWriter) end() {
+	w.sb.WriteString("ER  - \r\n")
+}
+
+// Stringerインターフェースの実装
+func (w *risWriter) String() string {
+	return w.sb.String()
+}
+
+// ToRIS はレコードをRIS形式に変換するメソッド。Descriptionのないレコードは空文字列を返す
+func (r *Record) ToRIS() string {
+	if len(r.Descriptions) == 0 {
+		return ""
+	}
+	d := r.Descriptions[0]
+	w := &risWriter{}
+
+	if r.Kind() == KindJournal {
+		w.add("TY", "JFULL")
+	} else {
+		w.add("TY", "BOOK")
+	}
+	title, _ := r.TitleWithReading()
+	w.add("TI", title.Title)
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			w.add("AU", ParseName(author[0]).Format(NameInverted))
+		}
+	}
+	if publishers, ok := r.Publishers(); ok {
+		w.add("PB", publishers[0].Name)
+		w.add("CY", publishers[0].Place)
+	}
+	w.add("PY", firstYear(d.Date))
+	w.add("ET", d.Edition)
+	w.add("LA", d.Language)
+	if isbns, ok := r.ISBNs(); ok {
+		for _, isbn := range isbns {
+			w.add("SN", string(isbn))
+		}
+	}
+	if issns, ok := r.ISSNs(); ok {
+		for _, issn := range issns {
+			w.add("SN", issn)
+		}
+	}
+	if topics, ok := r.Topics(); ok {
+		for _, topic := range topics {
+			w.add("KW", topic)
+		}
+	}
+	if len(d.NCID) > 0 {
+		w.add("N1", "NCID: "+d.NCID)
+		w.add("UR", NCID(d.NCID).URL())
+	}
+	w.end()
+	return w.String()
+}
+
+// ToRIS は検索結果のエントリをRIS形式に変換するメソッド
+func (e *Entry) ToRIS() string {
+	w := &risWriter{}
+	w.add("TY", "BOOK")
+	w.add("TI", e.Title)
+	for _, author := range e.Authors {
+		w.add("AU", author.Name)
+	}
+	publisher := ParsePublisher(e.Publisher)
+	w.add("PB", publisher.Name)
+	w.add("CY", publisher.Place)
+	w.add("PY", firstYear(e.PubDate))
+	isbns, _ := e.ISBNs()
+	for _, isbn := range isbns {
+		w.add("SN", string(isbn))
+	}
+	issns, _ := e.ISSNs()
+	for _, issn := range issns {
+		w.add("SN", issn)
+	}
+	w.add("UR", e.ID)
+	w.end()
+	return w.String()
+}
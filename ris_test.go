@@ -0,0 +1,30 @@
+package cinii
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRISWriter(t *testing.T) {
+	w := &risWriter{}
+	w.add("TY", "BOOK")
+	w.add("TI", " 吾輩は\n猫である ")
+	w.add("AU", "")
+	w.end()
+
+	want := "TY  - BOOK\r\nTI  - 吾輩は 猫である\r\nER  - \r\n"
+	if got := w.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := w.String(); got != want {
+		t.Errorf("second String() = %q, want %q", got, want)
+	}
+}
+
+func TestRecordToRIS(t *testing.T) {
+	r := parseTestRecord(t, "testdata/BA12345678.rdf", Parse)
+	got := r.ToRIS()
+	if !strings.HasPrefix(got, "TY  - BOOK\r\n") || !strings.HasSuffix(got, "\r\nER  - \r\n") || strings.Count(got, "ER  - ") != 1 {
+		t.Errorf("ToRIS() = %q", got)
+	}
+}
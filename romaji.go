@@ -0,0 +1,190 @@
+package cinii
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RomajiStyle はローマ字の表記法
+type RomajiStyle int
+
+// ローマ字の表記法の定数
+const (
+	RomajiALALC RomajiStyle = iota // ALA-LC（修正ヘボン式）。長音はマクロン、母音とyの前の撥音は"n'"
+	RomajiASCII                    // ALA-LCからマクロンとアポストロフィを除いた表記
+)
+
+// kanaRomaji はカタカナとヘボン式ローマ字の対応。2文字の拗音等を含む
+var kanaRomaji = map[string]string{
+	"ア": "a", "イ": "i", "ウ": "u", "エ": "e", "オ": "o",
+	"カ": "ka", "キ": "ki", "ク": "ku", "ケ": "ke", "コ": "ko",
+	"サ": "sa", "シ": "shi", "ス": "su", "セ": "se", "ソ": "so",
+	"タ": "ta", "チ": "chi", "ツ": "tsu", "テ": "te", "ト": "to",
+	"ナ": "na", "ニ": "ni", "ヌ": "nu", "ネ": "ne", "ノ": "no",
+	"ハ": "ha", "ヒ": "hi", "フ": "fu", "ヘ": "he", "ホ": "ho",
+	"マ": "ma", "ミ": "mi", "ム": "mu", "メ": "me", "モ": "mo",
+	"ヤ": "ya", "ユ": "yu", "ヨ": "yo",
+	"ラ": "ra", "リ": "ri", "ル": "ru", "レ": "re", "ロ": "ro",
+	"ワ": "wa", "ヰ": "i", "ヱ": "e", "ヲ": "o",
+	"ガ": "ga", "ギ": "gi", "グ": "gu", "ゲ": "ge", "ゴ": "go",
+	"ザ": "za", "ジ": "ji", "ズ": "zu", "ゼ": "ze", "ゾ": "zo",
+	"ダ": "da", "ヂ": "ji", "ヅ": "zu", "デ": "de", "ド": "do",
+	"バ": "ba", "ビ": "bi", "ブ": "bu", "ベ": "be", "ボ": "bo",
+	"パ": "pa", "ピ": "pi", "プ": "pu", "ペ": "pe", "ポ": "po",
+	"ヴ": "vu",
+	"ァ": "a", "ィ": "i", "ゥ": "u", "ェ": "e", "ォ": "o",
+	"ャ": "ya", "ュ": "yu", "ョ": "yo", "ヮ": "wa",
+
+	"キャ": "kya", "キュ": "kyu", "キョ": "kyo",
+	"シャ": "sha", "シュ": "shu", "ショ": "sho", "シェ": "she",
+	"チャ": "cha", "チュ": "chu", "チョ": "cho", "チェ": "che",
+	"ニャ": "nya", "ニュ": "nyu", "ニョ": "nyo",
+	"ヒャ": "hya", "ヒュ": "hyu", "ヒョ": "hyo",
+	"ミャ": "mya", "ミュ": "myu", "ミョ": "myo",
+	"リャ": "rya", "リュ": "ryu", "リョ": "ryo",
+	"ギャ": "gya", "ギュ": "gyu", "ギョ": "gyo",
+	"ジャ": "ja", "ジュ": "ju", "ジョ": "jo", "ジェ": "je",
+	"ヂャ": "ja", "ヂュ": "ju", "ヂョ": "jo",
+	"ビャ": "bya", "ビュ": "byu", "ビョ": "byo",
+	"ピャ": "pya", "ピュ": "pyu", "ピョ": "pyo",
+	"ファ": "fa", "フィ": "fi", "フェ": "fe", "フォ": "fo", "フュ": "fyu",
+	"ヴァ": "va", "ヴィ": "vi", "ヴェ": "ve", "ヴォ": "vo",
+	"ティ": "ti", "ディ": "di", "トゥ": "tu", "ドゥ": "du", "デュ": "dyu",
+	"ウィ": "wi", "ウェ": "we", "ウォ": "wo",
+	"ツァ": "tsa", "ツィ": "tsi", "ツェ": "tse", "ツォ": "tso",
+	"イェ": "ye", "クァ": "kwa", "グァ": "gwa",
+}
+
+// macrons は長音のマクロン付きの母音
+var macrons = map[byte]string{'a': "ā", 'i': "ī", 'u': "ū", 'e': "ē", 'o': "ō"}
+
+// stripMacrons はマクロンとアポストロフィを除くReplacer
+var stripMacrons = strings.NewReplacer("ā", "a", "ī", "i", "ū", "u", "ē", "e", "ō", "o", "'", "")
+
+// toKatakana はひらがなをカタカナに変換する関数
+func toKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if 'ぁ' <= r && r <= 'ゖ' || r == 'ゝ' || r == 'ゞ' {
+			return r + 'ァ' - 'ぁ'
+		}
+		return r
+	}, s)
+}
+
+// isVowel はローマ字の母音かを返す関数
+func isVowel(c byte) bool {
+	return strings.IndexByte("aiueo", c) >= 0
+}
+
+// Romanize はカタカナ、ひらがなの読みをヘボン式ローマ字に変換する関数。
+// 仮名以外の文字はそのまま残す。"オウ"、"ウウ"、長音符"ー"は長音として扱う
+func Romanize(s string, style RomajiStyle) string {
+	kana := []rune(toKatakana(s))
+	var b strings.Builder
+	sokuon := false
+	last := byte(0) // 直前に書き出した音節の母音
+	for i := 0; i < len(kana); i++ {
+		r := kana[i]
+		var syllable string
+		if i+1 < len(kana) {
+			if v, ok := kanaRomaji[string(kana[i:i+2])]; ok {
+				syllable = v
+				i++
+			}
+		}
+		if len(syllable) == 0 {
+			switch r {
+			case 'ッ':
+				sokuon = true
+				continue
+			case 'ン':
+				b.WriteString("n")
+				if i+1 < len(kana) {
+					if next, ok := kanaRomaji[string(kana[i+1])]; ok && (isVowel(next[0]) || next[0] == 'y') {
+						b.WriteString("'")
+					}
+				}
+				last = 0
+				continue
+			case 'ー':
+				if m, ok := macrons[last]; ok {
+					str := b.String()
+					b.Reset()
+					b.WriteString(str[:len(str)-1] + m)
+					last = 0
+				}
+				continue
+			}
+			syllable = kanaRomaji[string(r)]
+		}
+		if len(syllable) == 0 {
+			if sokuon {
+				b.WriteRune('ッ')
+			}
+			sokuon = false
+			last = 0
+			b.WriteRune(r)
+			continue
+		}
+
+		// 長音: "オウ"、"オオ"、"ウウ"
+		if (last == 'o' && (syllable == "u" || syllable == "o")) || (last == 'u' && syllable == "u") {
+			str := b.String()
+			b.Reset()
+			b.WriteString(str[:len(str)-1] + macrons[last])
+			last = 0
+			continue
+		}
+		if sokuon {
+			if strings.HasPrefix(syllable, "ch") {
+				b.WriteString("t")
+			} else if !isVowel(syllable[0]) {
+				b.WriteByte(syllable[0])
+			}
+			sokuon = false
+		}
+		b.WriteString(syllable)
+		last = syllable[len(syllable)-1]
+	}
+	if style == RomajiASCII {
+		return stripMacrons.Replace(b.String())
+	}
+	return b.String()
+}
+
+// capitalize は語の先頭の文字を大文字にする関数
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// RomanizedTitle はレコードのタイトルの読みをローマ字に変換して返すメソッド。先頭の語のみ大文字で始める
+func (r *Record) RomanizedTitle(style RomajiStyle) (string, bool) {
+	title, ok := r.TitleWithReading()
+	if !ok || len(title.Reading) == 0 {
+		return "", false
+	}
+	return capitalize(Romanize(title.Reading, style)), true
+}
+
+// RomanizedAuthors はレコードの著者の読みをローマ字に変換し、Authors()と同じ順序で返すメソッド。
+// 各語を大文字で始め、読みのない著者は空とする
+func (r *Record) RomanizedAuthors(style RomajiStyle) (ret []string, ok bool) {
+	authors, ok := r.Authors()
+	if !ok {
+		return nil, false
+	}
+	ret = make([]string, len(authors))
+	for i, author := range authors {
+		words := strings.Fields(Romanize(author[1], style))
+		for j, w := range words {
+			words[j] = capitalize(w)
+		}
+		ret[i] = strings.Join(words, " ")
+	}
+	return ret, true
+}
@@ -0,0 +1,60 @@
+package cinii
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRomanize(t *testing.T) {
+	tests := []struct {
+		in    string
+		style RomajiStyle
+		want  string
+	}{
+		{"ワガハイ ワ ネコ デアル", RomajiALALC, "wagahai wa neko dearu"},
+		{"とうきょう", RomajiALALC, "tōkyō"},
+		{"トウキョウ", RomajiALALC, "tōkyō"},
+		{"トウキョウ", RomajiASCII, "tokyo"},
+		{"オオサカ", RomajiALALC, "ōsaka"},
+		{"ユウキ", RomajiALALC, "yūki"},
+		{"イイ", RomajiALALC, "ii"},
+		{"コーヒー", RomajiALALC, "kōhī"},
+		{"スーパー", RomajiALALC, "sūpā"},
+		{"ラーメン", RomajiASCII, "ramen"},
+		{"ガッコウ", RomajiALALC, "gakkō"},
+		{"キップ", RomajiALALC, "kippu"},
+		{"ざっし", RomajiALALC, "zasshi"},
+		{"マッチャ", RomajiALALC, "matcha"},
+		{"シンブン", RomajiALALC, "shinbun"},
+		{"ゲンイン", RomajiALALC, "gen'in"},
+		{"ゲンイン", RomajiASCII, "genin"},
+		{"キンヨウビ", RomajiALALC, "kin'yōbi"},
+		{"ジョウホウ", RomajiALALC, "jōhō"},
+		{"ヴァイオリン", RomajiALALC, "vaiorin"},
+		{"ティーカップ", RomajiALALC, "tīkappu"},
+		{"NHK ホウソウ", RomajiALALC, "NHK hōsō"},
+		{"", RomajiALALC, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Romanize(tt.in, tt.style); got != tt.want {
+				t.Errorf("Romanize(%q, %d) = %q, want %q", tt.in, tt.style, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRomanizedRecord(t *testing.T) {
+	r := citationRecord(t)
+	if got, ok := r.RomanizedTitle(RomajiALALC); !ok || got != "Wagahai wa neko dearu" {
+		t.Errorf("RomanizedTitle() = %q, %v", got, ok)
+	}
+	want := []string{"Natsume, Sōseki"}
+	if got, ok := r.RomanizedAuthors(RomajiALALC); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("RomanizedAuthors() = %q, %v, want %q", got, ok, want)
+	}
+	want = []string{"Natsume, Soseki"}
+	if got, ok := r.RomanizedAuthors(RomajiASCII); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("RomanizedAuthors(RomajiASCII) = %q, %v, want %q", got, ok, want)
+	}
+}
@@ -0,0 +1,124 @@
+package cinii
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// schemaThing はschema.orgの項目の構造体
+type schemaThing struct {
+	Context       string         `json:"@context,omitempty"`
+	Type          string         `json:"@type"`
+	ID            string         `json:"@id,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	AlternateName []string       `json:"alternateName,omitempty"`
+	Author        []schemaThing  `json:"author,omitempty"`
+	Publisher     []schemaThing  `json:"publisher,omitempty"`
+	Location      string         `json:"location,omitempty"`
+	DatePublished string         `json:"datePublished,omitempty"`
+	InLanguage    string         `json:"inLanguage,omitempty"`
+	BookEdition   string         `json:"bookEdition,omitempty"`
+	ISBN          []string       `json:"isbn,omitempty"`
+	ISSN          []string       `json:"issn,omitempty"`
+	About         []string       `json:"about,omitempty"`
+	IsPartOf      []schemaThing  `json:"isPartOf,omitempty"`
+	WorkExample   []schemaThing  `json:"workExample,omitempty"`
+	SameAs        []string       `json:"sameAs,omitempty"`
+	URL           string         `json:"url,omitempty"`
+	Identifier    []schemaPValue `json:"identifier,omitempty"`
+}
+
+// schemaPValue はschema.orgのPropertyValueの構造体
+type schemaPValue struct {
+	Type       string `json:"@type"`
+	PropertyID string `json:"propertyID"`
+	Value      string `json:"value"`
+}
+
+// ToSchemaOrg はレコードをschema.orgのBook（雑誌はPeriodical）のJSON-LDに変換するメソッド。
+// 巻ごとのISBNはworkExampleとして出力する。Descriptionのないレコードはエラーとする
+func (r *Record) ToSchemaOrg() ([]byte, error) {
+	if len(r.Descriptions) == 0 {
+		return nil, fmt.Errorf("cinii: no description")
+	}
+	d := r.Descriptions[0]
+
+	book := schemaThing{Context: "https://schema.org", Type: "Book"}
+	if r.Kind() == KindJournal {
+		book.Type = "Periodical"
+	}
+	if len(d.NCID) > 0 {
+		book.ID = NCID(d.NCID).URL()
+		book.URL = book.ID
+		book.Identifier = []schemaPValue{{"PropertyValue", "NCID", d.NCID}}
+	}
+	if title, ok := r.TitleWithReading(); ok {
+		book.Name = title.Title
+		if len(title.Reading) > 0 {
+			book.AlternateName = append(book.AlternateName, title.Reading)
+		}
+	}
+	if alternatives, ok := r.AlternativeTitles(); ok {
+		for _, t := range alternatives {
+			if len(t.Title) > 0 {
+				book.AlternateName = append(book.AlternateName, t.Title)
+			}
+		}
+	}
+
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			person := schemaThing{Type: "Person", Name: author[0]}
+			if len(author[1]) > 0 {
+				person.AlternateName = []string{author[1]}
+			}
+			if len(author[2]) > 0 {
+				person.ID = "https://ci.nii.ac.jp/author/" + author[2]
+			}
+			book.Author = append(book.Author, person)
+		}
+	}
+	if publishers, ok := r.Publishers(); ok {
+		for _, p := range publishers {
+			book.Publisher = append(book.Publisher, schemaThing{Type: "Organization", Name: p.Name, Location: p.Place})
+		}
+	}
+	book.DatePublished = firstYear(d.Date)
+	book.InLanguage = strings.TrimSpace(d.Language)
+	book.BookEdition = strings.TrimSpace(d.Edition)
+	book.ISSN, _ = r.ISSNs()
+	if topics, ok := r.Topics(); ok {
+		book.About = topics
+	}
+	if series, ok := r.SeriesInfo(); ok {
+		for _, s := range series {
+			part := schemaThing{Type: "BookSeries", Name: s.Title}
+			if len(s.NCID) > 0 {
+				part.ID = NCID(s.NCID).URL()
+			}
+			book.IsPartOf = append(book.IsPartOf, part)
+		}
+	}
+
+	if isbns, ok := r.ISBNs(); ok {
+		for _, isbn := range isbns {
+			book.ISBN = append(book.ISBN, string(isbn))
+		}
+	}
+	// 複数の巻がある場合は巻ごとの版（workExample）とする
+	if volumes, ok := r.Volumes(); ok && len(volumes) > 1 {
+		for _, volume := range volumes {
+			example := schemaThing{
+				Type:        "Book",
+				Name:        strings.TrimSpace(book.Name + " " + volume[0]),
+				BookEdition: volume[0],
+			}
+			if isbn, err := NewISBN(volume[1]); err == nil {
+				example.ISBN = []string{string(isbn)}
+			}
+			book.WorkExample = append(book.WorkExample, example)
+		}
+	}
+	return json.MarshalIndent(book, "", "  ")
+}
@@ -1,12 +1,15 @@
 package cinii
 
 import (
+	"bytes"
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"html"
-	"io/ioutil"
-	"net/http"
+	"io"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -15,90 +18,305 @@ const OpenSaerchEndpoint = "http://ci.nii.ac.jp/books/opensearch/search"
 
 // AtomFeed はAtom1.0レスポンス構造体
 type AtomFeed struct {
-	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
-	Title   string   `xml:"http://www.w3.org/2005/Atom title"`
-	Links   []struct {
-		Rel  string `xml:"rel,attr"`
-		Type string `xml:"type,attr"`
-		Href string `xml:"href,attr"`
-	} `xml:"http://www.w3.org/2005/Atom link"`
+	XMLName      xml.Name   `xml:"http://www.w3.org/2005/Atom feed"`
+	Title        string     `xml:"http://www.w3.org/2005/Atom title"`
+	Links        []Link     `xml:"http://www.w3.org/2005/Atom link"`
 	ID           string     `xml:"http://www.w3.org/2005/Atom id"`
 	Updated      customTime `xml:"http://www.w3.org/2005/Atom updated"`
 	TotalResults int        `xml:"http://a9.com/-/spec/opensearch/1.1/ totalResults"`
 	StartIndex   int        `xml:"http://a9.com/-/spec/opensearch/1.1/ startIndex"`
 	ItemsPerPage int        `xml:"http://a9.com/-/spec/opensearch/1.1/ itemsPerPage"`
 	Entries      []Entry    `xml:"http://www.w3.org/2005/Atom entry"`
+
+	Warnings []string `xml:"-"` // 解析できずゼロ値とした日時等の警告
+}
+
+// Link はAtomのlink要素の構造体
+type Link struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+	Href string `xml:"href,attr"`
 }
 
 // HTMLLink はAtomFeedからHTML Linkを返すメソッド
 func (f *AtomFeed) HTMLLink() (link string, err error) {
+	if len(f.Links) == 0 {
+		return "", errors.New("cinii: feed has no link")
+	}
 	link = html.UnescapeString(f.Links[0].Href)
 	link, err = url.QueryUnescape(link)
 	return
 }
 
+// link はrel属性が一致する最初のリンクのURLを返すメソッド
+func (f *AtomFeed) link(rel string) (string, bool) {
+	for _, l := range f.Links {
+		if l.Rel == rel && len(l.Href) > 0 {
+			return html.UnescapeString(l.Href), true
+		}
+	}
+	return "", false
+}
+
+// NextPage は次のページのURL（rel="next"）を返すメソッド
+func (f *AtomFeed) NextPage() (string, bool) {
+	return f.link("next")
+}
+
+// PrevPage は前のページのURL（rel="prev"または"previous"）を返すメソッド
+func (f *AtomFeed) PrevPage() (string, bool) {
+	if href, ok := f.link("prev"); ok {
+		return href, true
+	}
+	return f.link("previous")
+}
+
+// FirstPage は最初のページのURL（rel="first"）を返すメソッド
+func (f *AtomFeed) FirstPage() (string, bool) {
+	return f.link("first")
+}
+
+// LastPage は最後のページのURL（rel="last"）を返すメソッド
+func (f *AtomFeed) LastPage() (string, bool) {
+	return f.link("last")
+}
+
+// HasNext は次のページがあるかを返すメソッド。総件数と1ページの件数から判定し、
+// それらがない場合はrel="next"のリンクの有無で判定する
+func (f *AtomFeed) HasNext() bool {
+	if f.TotalResults > 0 && f.ItemsPerPage > 0 {
+		start := f.StartIndex
+		if start < 1 {
+			start = 1
+		}
+		return start-1+f.ItemsPerPage < f.TotalResults
+	}
+	_, ok := f.NextPage()
+	return ok
+}
+
+// TotalPages は総件数と1ページの件数から求めたページ数を返すメソッド。1ページの件数がない場合は0
+func (f *AtomFeed) TotalPages() int {
+	if f.ItemsPerPage <= 0 {
+		return 0
+	}
+	return (f.TotalResults + f.ItemsPerPage - 1) / f.ItemsPerPage
+}
+
 // Entry はAtomFeedのエントリ構造体
 type Entry struct {
-	Title   string `xml:"http://www.w3.org/2005/Atom title"`
-	ID      string `xml:"http://www.w3.org/2005/Atom id"`
-	Authors []struct {
-		Name string `xml:"http://www.w3.org/2005/Atom name"`
-	} `xml:"http://www.w3.org/2005/Atom author"`
-	Publisher string `xml:"http://purl.org/dc/elements/1.1/ publisher"`
-	PubDate   string `xml:"http://prismstandard.org/namespaces/basic/2.0/ publicationDate"`
-	IsPartOf  []struct {
-		Title string `xml:"title,attr"`
-		Link  string `xml:",chardata"`
-	} `xml:"http://purl.org/dc/terms/ isPartOf"`
-	HasPart    []string `xml:"http://purl.org/dc/terms/ hasPart"`
-	OwnerCount int      `xml:"http://ci.nii.ac.jp/ns/1.0/ ownerCount"`
+	Title      string        `xml:"http://www.w3.org/2005/Atom title"`
+	ID         string        `xml:"http://www.w3.org/2005/Atom id"`
+	Authors    []EntryAuthor `xml:"http://www.w3.org/2005/Atom author"`
+	Publisher  string        `xml:"http://purl.org/dc/elements/1.1/ publisher"`
+	PubDate    string        `xml:"http://prismstandard.org/namespaces/basic/2.0/ publicationDate"`
+	IsPartOf   []EntryPart   `xml:"http://purl.org/dc/terms/ isPartOf"`
+	HasPart    []string      `xml:"http://purl.org/dc/terms/ hasPart"`
+	OwnerCount int           `xml:"http://ci.nii.ac.jp/ns/1.0/ ownerCount"`
+	Links      []Link        `xml:"http://www.w3.org/2005/Atom link"`
+	Updated    customTime    `xml:"http://www.w3.org/2005/Atom updated"`
+	Creator    string        `xml:"http://purl.org/dc/elements/1.1/ creator"` // 責任表示
+	ISSN       string        `xml:"http://prismstandard.org/namespaces/basic/2.0/ issn"`
 }
 
-type customTime struct {
-	time.Time
+// EntryAuthor はエントリの著者の構造体
+type EntryAuthor struct {
+	Name string `xml:"http://www.w3.org/2005/Atom name"`
 }
 
-func (c *customTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	var v string
-	d.DecodeElement(&v, &start)
-	//parse, err := time.Parse("2006-01-02T15:04:05-0700", v)
-	// RFC3339: 2006-01-02T15:04:05-07:00
-	parse, err := time.Parse(time.RFC3339, v)
+// EntryPart はエントリの親書誌（dcterms:isPartOf）の構造体
+type EntryPart struct {
+	Title string `xml:"title,attr"`
+	Link  string `xml:",chardata"`
+}
+
+// alternateURL はrel属性がalternate（または省略）で、typeが一致するリンクのURLを返すメソッド。
+// mediaTypeがtext/htmlの場合はtypeの省略されたリンクも一致とする
+func (e *Entry) alternateURL(mediaType string) (string, bool) {
+	for _, l := range e.Links {
+		if l.Rel != "" && l.Rel != "alternate" || len(l.Href) == 0 {
+			continue
+		}
+		if t := strings.TrimSpace(strings.SplitN(l.Type, ";", 2)[0]); t == mediaType || (t == "" && mediaType == "text/html") {
+			return html.UnescapeString(l.Href), true
+		}
+	}
+	return "", false
+}
+
+// HTMLURL はエントリのHTMLページのURLを返すメソッド
+func (e *Entry) HTMLURL() (string, bool) {
+	return e.alternateURL("text/html")
+}
+
+// RDFURL はエントリのRDF/XMLのURLを返すメソッド
+func (e *Entry) RDFURL() (string, bool) {
+	return e.alternateURL("application/rdf+xml")
+}
+
+// ISBNs はエントリの巻（dcterms:hasPart）の妥当なISBNを返すメソッド
+func (e *Entry) ISBNs() (ret []ISBN, ok bool) {
+	for _, part := range e.HasPart {
+		if isbn, err := NewISBN(part); err == nil {
+			ret = append(ret, isbn)
+		}
+	}
+	return ret, len(ret) > 0
+}
+
+// ISSNs はエントリのISSNを返すメソッド
+func (e *Entry) ISSNs() (ret []string, ok bool) {
+	if issn := strings.TrimSpace(e.ISSN); len(issn) > 0 {
+		ret = append(ret, formatISSN(issn))
+	}
+	return ret, len(ret) > 0
+}
+
+// Identifier はエントリのIDのURLから書誌のNCIDまたは論文のNAIDを取り出し、検証して返すメソッド
+func (e *Entry) Identifier() (Identifier, error) {
+	t, id, err := ParseResourceURL(e.ID)
 	if err != nil {
-		return err
+		return Identifier{}, err
 	}
-	*c = customTime{parse}
-	return nil
+	switch t {
+	case ResourceBook:
+		ncid, err := NewNCID(id)
+		if err != nil {
+			return Identifier{}, err
+		}
+		return Identifier{IdentifierNCID, string(ncid)}, nil
+	case ResourceArticle:
+		if !naidPattern.MatchString(id) {
+			return Identifier{}, fmt.Errorf("cinii: invalid NAID: %q", id)
+		}
+		return Identifier{IdentifierNAID, id}, nil
+	}
+	return Identifier{}, fmt.Errorf("cinii: entry is neither a book nor an article: %s", e.ID)
 }
 
-// Search はCiniiBooksをOpenSearchで検索する
-func Search(q url.Values) (*AtomFeed, error) {
-	url := fmt.Sprintf("%s?%s", OpenSaerchEndpoint, q.Encode())
-	resp, err := http.Get(url)
+// NCID はエントリのIDのURLからNCIDを取り出し、検証して返すメソッド
+func (e *Entry) NCID() (NCID, error) {
+	id, err := e.Identifier()
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	if id.Type != IdentifierNCID {
+		return "", fmt.Errorf("cinii: entry has no NCID: %s", e.ID)
 	}
-	defer resp.Body.Close()
+	return NCID(id.Value), nil
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+// NAID はエントリのIDのURLから論文のNAIDを取り出し、検証して返すメソッド
+func (e *Entry) NAID() (string, error) {
+	id, err := e.Identifier()
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	if id.Type != IdentifierNAID {
+		return "", fmt.Errorf("cinii: entry has no NAID: %s", e.ID)
 	}
-	feed, err := ParseAtomFeed(body)
+	return id.Value, nil
+}
+
+// Fetch はエントリの書誌の詳細をclientで取得し、Record構造体のポインタで返すメソッド。
+// リクエストはclientのMinIntervalに従って送信する。clientがnilの場合はパッケージのHTTPClientを使う
+func (e *Entry) Fetch(ctx context.Context, client *Client, opts ...ParseOption) (*Record, error) {
+	ncid, err := e.NCID()
 	if err != nil {
 		return nil, err
 	}
-	return feed, nil
+	return client.Get(ctx, string(ncid), opts...)
+}
+
+// timeLayouts はcustomTimeで試す日時の形式
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// customTime は複数の形式を受け付ける日時。解析できない場合はゼロ値とし、元の文字列とエラーを保持する
+type customTime struct {
+	time.Time
+	raw string
+	err error
+}
+
+// UnmarshalXML はxml.Unmarshalerインターフェースの実装。日時を解析できなくてもエラーとしない
+func (c *customTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v string
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*c = parseCustomTime(v)
+	return nil
+}
+
+// parseCustomTime はtimeLayoutsの形式を順に試して日時を解析する関数
+func parseCustomTime(v string) customTime {
+	v = strings.TrimSpace(v)
+	if len(v) == 0 {
+		return customTime{}
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return customTime{Time: t, raw: v}
+		}
+	}
+	return customTime{raw: v, err: fmt.Errorf("cinii: unknown time format: %q", v)}
+}
+
+// Search はCiniiBooksをOpenSearchで検索する
+func Search(q url.Values) (*AtomFeed, error) {
+	return SearchContext(context.Background(), q)
+}
+
+// SearchContext はCiNii BooksをOpenSearchで検索する関数。ctxが取り消された場合は検索を中止する
+func SearchContext(ctx context.Context, q url.Values) (*AtomFeed, error) {
+	return (*Client)(nil).Search(ctx, q)
 }
 
 // ParseAtomFeed はAtomFeedを含むbyte[]を受け取りAtomFeed構造体のポインタで返す関数
 func ParseAtomFeed(body []byte) (*AtomFeed, error) {
+	return decodeAtomFeed(bytes.NewReader(body))
+}
+
+// decodeFeed はOpenSearchのレスポンスをformatの形式で解析し、AtomFeed構造体のポインタで返す関数
+func decodeFeed(r io.Reader, format string) (*AtomFeed, error) {
+	switch format {
+	case FormatJSON.String():
+		return decodeJSONFeed(r)
+	case FormatRSS.String():
+		return decodeRSSFeed(r)
+	}
+	return decodeAtomFeed(r)
+}
+
+// decodeAtomFeed はAtomFeedを読み出すio.Readerを受け取り、逐次デコードしたAtomFeed構造体のポインタで返す関数
+func decodeAtomFeed(r io.Reader) (*AtomFeed, error) {
 	// 取得したデータをXMLデコード
 	feed := &AtomFeed{}
-	err := xml.Unmarshal(body, feed)
+	err := newXMLDecoder(r).Decode(feed)
 	if err != nil {
 		return nil, err
 	}
+	feed.collectWarnings()
 
 	return feed, nil
 }
+
+// collectWarnings は解析できなかった日時をWarningsに記録するメソッド
+func (f *AtomFeed) collectWarnings() {
+	if err := f.Updated.err; err != nil {
+		f.Warnings = append(f.Warnings, "updated: "+err.Error())
+	}
+	for i := range f.Entries {
+		if err := f.Entries[i].Updated.err; err != nil {
+			f.Warnings = append(f.Warnings, fmt.Sprintf("entry %d: updated: %s", i+1, err))
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package cinii
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseAtomFeed(t *testing.T) {
+	body, err := os.ReadFile("testdata/opensearch.atom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed, err := ParseAtomFeed(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feed.TotalResults != 1234 || feed.StartIndex != 1 || feed.ItemsPerPage != 20 {
+		t.Errorf("totalResults, startIndex, itemsPerPage = %d, %d, %d", feed.TotalResults, feed.StartIndex, feed.ItemsPerPage)
+	}
+	if len(feed.Entries) != 20 {
+		t.Fatalf("len(Entries) = %d, want 20", len(feed.Entries))
+	}
+	if !feed.HasNext() {
+		t.Error("HasNext() = false")
+	}
+	e := feed.Entries[0]
+	if ncid, err := e.NCID(); err != nil || ncid != "BA12345678" {
+		t.Errorf("NCID() = %q, %v", ncid, err)
+	}
+	if len(e.IsPartOf) != 1 || e.IsPartOf[0].Title != "岩波文庫" {
+		t.Errorf("IsPartOf = %+v", e.IsPartOf)
+	}
+	if len(feed.Warnings) > 0 {
+		t.Errorf("Warnings = %v", feed.Warnings)
+	}
+}
+
+func BenchmarkParseAtomFeed(b *testing.B) {
+	body, err := os.ReadFile("testdata/opensearch.atom")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseAtomFeed(body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package cinii
+
+import "strings"
+
+// PartInfo はcinii:partInfo構造体。親書誌のタイトル、読み、巻次を持つ
+type PartInfo struct {
+	ResourceAttr
+	Title  TextFields `xml:"http://purl.org/dc/elements/1.1/ title"`
+	Volume string     `xml:"http://ci.nii.ac.jp/ns/1.0/ volume"`
+}
+
+// SeriesInfo はシリーズ情報の構造体
+type SeriesInfo struct {
+	Title   string
+	Reading string
+	Volume  string // シリーズ内の巻次
+	NCID    string
+}
+
+// splitSeriesTitle は"シリーズ名 ; 巻次"形式のタイトルをシリーズ名と巻次に分割する関数
+func splitSeriesTitle(s string) (title, volume string) {
+	if i := strings.LastIndex(s, " ; "); i >= 0 {
+		return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(" ; "):])
+	}
+	return strings.TrimSpace(s), ""
+}
+
+// SeriesInfo はレコードからisPartOfとpartInfoを組み合わせたシリーズ情報の配列を返すメソッド
+func (r *Record) SeriesInfo() (ret []SeriesInfo, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	d := r.Descriptions[0]
+	if len(d.IsPartOf) == 0 {
+		return nil, false
+	}
+
+	ret = make([]SeriesInfo, len(d.IsPartOf))
+	for i, field := range d.IsPartOf {
+		series := &ret[i]
+		series.Title, series.Volume = splitSeriesTitle(field.Title)
+		series.NCID = trimResourceURI(field.Resource)
+
+		// resourceが一致するpartInfo、なければ同じ位置のpartInfoで補う
+		var info *PartInfo
+		for j := range d.PartInfo {
+			if len(d.PartInfo[j].Resource) > 0 && d.PartInfo[j].Resource == field.Resource {
+				info = &d.PartInfo[j]
+				break
+			}
+		}
+		if info == nil && i < len(d.PartInfo) && len(d.PartInfo[i].Resource) == 0 {
+			info = &d.PartInfo[i]
+		}
+		if info == nil {
+			continue
+		}
+		for _, t := range info.Title {
+			if len(t.Lang) > 0 {
+				series.Reading = t.Text
+			} else if len(series.Title) == 0 {
+				series.Title = t.Text
+			}
+		}
+		if v := strings.TrimSpace(info.Volume); len(v) > 0 {
+			series.Volume = v
+		}
+	}
+	return ret, true
+}
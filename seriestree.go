@@ -0,0 +1,173 @@
+package cinii
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SeriesNode はシリーズ階層の書誌のノード構造体
+type SeriesNode struct {
+	NCID     string
+	Record   *Record
+	Parents  []*SeriesNode
+	Children []*SeriesNode
+}
+
+// Title はノードの書誌のタイトルを返すメソッド
+func (n *SeriesNode) Title() string {
+	if n.Record == nil {
+		return ""
+	}
+	title, _ := n.Record.TitleWithReading()
+	return title.Title
+}
+
+// SeriesTree はある書誌から親書誌（isPartOf）と巻（hasPart）をたどって作成したシリーズ階層の構造体
+type SeriesTree struct {
+	Start  *SeriesNode            // 起点の書誌
+	Nodes  map[string]*SeriesNode // NCIDをキーとするすべてのノード
+	Cycles [][2]string            // 循環となるため除いた[親, 子]のリンク
+}
+
+// Roots は親書誌を持たないノードの配列をNCID順で返すメソッド
+func (t *SeriesTree) Roots() (ret []*SeriesNode) {
+	for _, n := range t.Nodes {
+		if len(n.Parents) == 0 {
+			ret = append(ret, n)
+		}
+	}
+	sortNodes(ret)
+	return
+}
+
+// String はルートからの階層を字下げして返すメソッド
+func (t *SeriesTree) String() string {
+	var sb strings.Builder
+	visited := make(map[*SeriesNode]bool)
+	var walk func(n *SeriesNode, depth int)
+	walk = func(n *SeriesNode, depth int) {
+		fmt.Fprintf(&sb, "%s%s %s\n", strings.Repeat("  ", depth), n.NCID, n.Title())
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		for _, child := range n.Children {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range t.Roots() {
+		walk(root, 0)
+	}
+	return sb.String()
+}
+
+// sortNodes はノードの配列をNCID順に並べ替える関数
+func sortNodes(nodes []*SeriesNode) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].NCID < nodes[j].NCID })
+}
+
+// node はNCIDのノードを返すメソッド。なければ作成する
+func (t *SeriesTree) node(ncid string) *SeriesNode {
+	n, ok := t.Nodes[ncid]
+	if !ok {
+		n = &SeriesNode{NCID: ncid}
+		t.Nodes[ncid] = n
+	}
+	return n
+}
+
+// isAncestor はaがnの祖先（またはn自身）か判定するメソッド
+func (t *SeriesTree) isAncestor(a, n *SeriesNode) bool {
+	visited := make(map[*SeriesNode]bool)
+	var walk func(n *SeriesNode) bool
+	walk = func(n *SeriesNode) bool {
+		if n == a {
+			return true
+		}
+		if visited[n] {
+			return false
+		}
+		visited[n] = true
+		for _, p := range n.Parents {
+			if walk(p) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(n)
+}
+
+// link は親子のリンクを追加するメソッド。重複するリンクは無視し、循環となるリンクはCyclesに記録する
+func (t *SeriesTree) link(parent, child *SeriesNode) {
+	for _, c := range parent.Children {
+		if c == child {
+			return
+		}
+	}
+	if t.isAncestor(child, parent) {
+		t.Cycles = append(t.Cycles, [2]string{parent.NCID, child.NCID})
+		return
+	}
+	parent.Children = append(parent.Children, child)
+	child.Parents = append(child.Parents, parent)
+}
+
+// BuildSeriesTree はNCIDの書誌を起点に親書誌と巻のリンクを再帰的にたどり、シリーズ階層を返す関数。
+// maxDepthは起点からたどるリンクの最大数で、0以下の場合は上限なしとする。
+// 同じ書誌は一度だけ取得し、循環するリンクは除いてSeriesTree.Cyclesに記録する
+func BuildSeriesTree(ctx context.Context, client *Client, ncid string, maxDepth int) (*SeriesTree, error) {
+	start, err := NewNCID(ncid)
+	if err != nil {
+		return nil, err
+	}
+	t := &SeriesTree{Nodes: make(map[string]*SeriesNode)}
+	t.Start = t.node(string(start))
+
+	frontier := []string{string(start)}
+	for depth := 0; len(frontier) > 0; depth++ {
+		records, err := client.fetchAll(ctx, frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []string
+		for i, record := range records {
+			n := t.node(frontier[i])
+			n.Record = record
+			if maxDepth > 0 && depth >= maxDepth {
+				continue
+			}
+
+			var parents []string
+			if ps, ok := record.Parents(); ok {
+				for _, p := range ps {
+					if id, err := NewNCID(p[1]); err == nil {
+						parents = append(parents, string(id))
+					}
+				}
+			}
+			children, err := record.volumeNCIDs(ctx, client)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, id := range parents {
+				if _, ok := t.Nodes[id]; !ok {
+					next = append(next, id)
+				}
+				t.link(t.node(id), n)
+			}
+			for _, id := range children {
+				if _, ok := t.Nodes[id]; !ok {
+					next = append(next, id)
+				}
+				t.link(n, t.node(id))
+			}
+		}
+		frontier = next
+	}
+	return t, nil
+}
@@ -0,0 +1,129 @@
+package cinii
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SubjectField はdc:subject構造体。"NDC8:913.6"のようなテキスト、
+// またはスキームと値をパスに含むresource属性で表される
+type SubjectField struct {
+	ResourceAttr
+	TitleAttr
+	Text string `xml:",chardata"`
+}
+
+// schemeValue はスキームと値を返すメソッド
+func (f SubjectField) schemeValue() (scheme, value string) {
+	text := strings.TrimSpace(f.Text)
+	if len(text) == 0 {
+		text = strings.TrimSpace(f.Title)
+	}
+	if i := strings.Index(text, ":"); i > 0 && !strings.ContainsAny(text[:i], " /") {
+		return strings.ToUpper(strings.TrimSpace(text[:i])), strings.TrimSpace(text[i+1:])
+	}
+	if len(f.Resource) > 0 {
+		segments := strings.Split(strings.Trim(trimExtension(f.Resource), "/"), "/")
+		if n := len(segments); n >= 2 {
+			value := text
+			if len(value) == 0 {
+				var err error
+				if value, err = url.PathUnescape(segments[n-1]); err != nil {
+					value = segments[n-1]
+				}
+			}
+			return strings.ToUpper(segments[n-2]), value
+		}
+	}
+	return "", text
+}
+
+// Classification は分類の構造体
+type Classification struct {
+	Scheme string // NDC8, NDC9, NDLC, LCC, DDC等
+	Value  string
+}
+
+// classificationSchemes はNDC以外の分類スキーム。NDCはisClassificationSchemeで版を問わず判定する
+var classificationSchemes = map[string]bool{
+	"NDLC": true,
+	"LCC":  true,
+	"DDC":  true,
+	"UDC":  true,
+	"NLMC": true,
+}
+
+// isClassificationScheme は分類のスキームか判定する関数。NDCはNDC、NDC8、NDC9等の版によらず分類とする
+func isClassificationScheme(scheme string) bool {
+	return strings.HasPrefix(scheme, "NDC") || classificationSchemes[scheme]
+}
+
+// Classifications はレコードから分類の配列を返すメソッド
+func (r *Record) Classifications() (ret []Classification, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	for _, field := range r.Descriptions[0].Subjects {
+		scheme, value := field.schemeValue()
+		if isClassificationScheme(scheme) && len(value) > 0 {
+			ret = append(ret, Classification{scheme, value})
+		}
+	}
+	return ret, len(ret) > 0
+}
+
+// SubjectHeading は件名の構造体
+type SubjectHeading struct {
+	Scheme  string // NDLSH, BSH, LCSH等
+	Heading string
+	URI     string // 典拠のURI
+}
+
+// subjectAuthorities は典拠URIの接頭辞と件名標目表の対応
+var subjectAuthorities = []struct {
+	prefix string
+	scheme string
+}{
+	{"http://id.ndl.go.jp/auth/ndlsh/", "NDLSH"},
+	{"https://id.ndl.go.jp/auth/ndlsh/", "NDLSH"},
+	{"http://id.ndl.go.jp/auth/bsh/", "BSH"},
+	{"https://id.ndl.go.jp/auth/bsh/", "BSH"},
+	{"http://id.loc.gov/authorities/subjects/", "LCSH"},
+	{"https://id.loc.gov/authorities/subjects/", "LCSH"},
+	{"http://id.nlm.nih.gov/mesh/", "MESH"},
+	{"https://id.nlm.nih.gov/mesh/", "MESH"},
+}
+
+// authorityScheme は典拠URIから件名標目表を返す関数
+func authorityScheme(uri string) string {
+	for _, a := range subjectAuthorities {
+		if strings.HasPrefix(uri, a.prefix) {
+			return a.scheme
+		}
+	}
+	return ""
+}
+
+// SubjectHeadings はレコードからfoaf:topicの自由語を除いた件名の配列を返すメソッド
+func (r *Record) SubjectHeadings() (ret []SubjectHeading, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	d := r.Descriptions[0]
+	for _, field := range d.Subjects {
+		scheme, value := field.schemeValue()
+		if len(scheme) == 0 || isClassificationScheme(scheme) || len(value) == 0 {
+			continue
+		}
+		if s := authorityScheme(field.Resource); len(s) > 0 {
+			scheme = s
+		}
+		ret = append(ret, SubjectHeading{scheme, value, field.Resource})
+	}
+	for _, topic := range d.Topics {
+		if scheme := authorityScheme(topic.Resource); len(scheme) > 0 {
+			ret = append(ret, SubjectHeading{scheme, topic.Title, topic.Resource})
+		}
+	}
+	return ret, len(ret) > 0
+}
@@ -0,0 +1,119 @@
+package cinii
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Column は表形式で出力する列
+type Column int
+
+// 列の定数
+const (
+	ColumnNCID       Column = iota // NCID
+	ColumnTitle                    // タイトル
+	ColumnReading                  // タイトルの読み
+	ColumnAuthors                  // 著者（"; "区切り）
+	ColumnPublisher                // 出版者（"; "区切り）
+	ColumnYear                     // 出版年（西暦）
+	ColumnOwnerCount               // 所蔵館数
+	ColumnISBNs                    // ISBN（"; "区切り）
+)
+
+var columnNames = []string{"ncid", "title", "reading", "authors", "publisher", "year", "owner_count", "isbns"}
+
+// Stringerインターフェースの実装。見出し行の列名を返す
+func (c Column) String() string {
+	if c >= 0 && int(c) < len(columnNames) {
+		return columnNames[c]
+	}
+	return ""
+}
+
+// DefaultColumns は列を指定しない場合に出力する列
+var DefaultColumns = []Column{
+	ColumnNCID, ColumnTitle, ColumnReading, ColumnAuthors,
+	ColumnPublisher, ColumnYear, ColumnOwnerCount, ColumnISBNs,
+}
+
+// Row はレコードから指定した列の値の配列を返すメソッド。列を省略した場合はDefaultColumnsとする
+func (r *Record) Row(columns ...Column) []string {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	d := r.Descriptions[0]
+	title, _ := r.TitleWithReading()
+
+	row := make([]string, len(columns))
+	for i, c := range columns {
+		switch c {
+		case ColumnNCID:
+			row[i] = d.NCID
+		case ColumnTitle:
+			row[i] = title.Title
+		case ColumnReading:
+			row[i] = title.Reading
+		case ColumnAuthors:
+			if authors, ok := r.Authors(); ok {
+				names := make([]string, len(authors))
+				for j, author := range authors {
+					names[j] = author[0]
+				}
+				row[i] = strings.Join(names, "; ")
+			}
+		case ColumnPublisher:
+			row[i] = strings.Join(d.Publisher, "; ")
+		case ColumnYear:
+			row[i] = firstYear(d.Date)
+		case ColumnOwnerCount:
+			row[i] = strconv.Itoa(r.holdingsCount())
+		case ColumnISBNs:
+			if isbns, ok := r.ISBNs(); ok {
+				values := make([]string, len(isbns))
+				for j, isbn := range isbns {
+					values[j] = string(isbn)
+				}
+				row[i] = strings.Join(values, "; ")
+			}
+		}
+	}
+	return row
+}
+
+// WriteCSV はレコードの配列を見出し行付きのCSVで書き出す関数。列を省略した場合はDefaultColumnsとする
+func WriteCSV(w io.Writer, records []*Record, columns ...Column) error {
+	return writeTable(csv.NewWriter(w), records, columns)
+}
+
+// WriteTSV はレコードの配列を見出し行付きのTSVで書き出す関数。列を省略した場合はDefaultColumnsとする
+func WriteTSV(w io.Writer, records []*Record, columns ...Column) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = '\t'
+	return writeTable(cw, records, columns)
+}
+
+// writeTable はcsv.Writerに見出し行とレコードの行を書き出す関数。nilとDescriptionのないレコードは除く
+func writeTable(cw *csv.Writer, records []*Record, columns []Column) error {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.String()
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if record == nil || len(record.Descriptions) == 0 {
+			continue
+		}
+		if err := cw.Write(record.Row(columns...)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
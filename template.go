@@ -0,0 +1,115 @@
+package cinii
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TemplateData はFormatRecordでテンプレートに渡すデータ構造体。
+// テンプレートでは {{.Title}} や {{range .Authors}}{{.Name}}{{end}} のように参照する
+type TemplateData struct {
+	NCID       string
+	URL        string // CiNii Booksの書誌のURL
+	Kind       Kind   // 資料種別。{{.Kind}}は"Book"等になる
+	Title      string
+	Reading    string // タイトルの読み
+	Authors    []TemplateAuthor
+	Creator    string // 責任表示
+	Publishers []Publisher
+	Date       string // 出版年（記述のまま）
+	Year       string // 出版年（西暦4桁）
+	Edition    string
+	Language   string
+	ISBNs      []string
+	ISSNs      []string
+	Series     []SeriesInfo
+	Topics     []string
+	Notes      []string
+	Holdings   []TemplateHolding
+	OwnerCount int     // 所蔵館数
+	Record     *Record // 元のレコード
+}
+
+// TemplateAuthor はTemplateDataの著者の構造体
+type TemplateAuthor struct {
+	Name    string
+	Reading string
+	ID      string // CiNii著者ID
+}
+
+// TemplateHolding はTemplateDataの所蔵館の構造体
+type TemplateHolding struct {
+	Name string
+	FAID string
+	URL  string // 所蔵館OPACのURL
+}
+
+// TemplateFuncs はテンプレートで利用できる関数。template.New("").Funcs(cinii.TemplateFuncs)のように設定する。
+//
+//	join  文字列の配列を区切り文字で連結する  {{join .ISBNs ", "}}
+//	upper 大文字に変換する
+//	lower 小文字に変換する
+var TemplateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// TemplateData はレコードからテンプレートに渡すデータを返すメソッド。Descriptionのないレコードはゼロ値を返す
+func (r *Record) TemplateData() TemplateData {
+	if len(r.Descriptions) == 0 {
+		return TemplateData{}
+	}
+	d := r.Descriptions[0]
+	title, _ := r.TitleWithReading()
+	data := TemplateData{
+		NCID:       d.NCID,
+		Kind:       r.Kind(),
+		Title:      title.Title,
+		Reading:    title.Reading,
+		Creator:    strings.TrimSpace(d.Creator),
+		Date:       strings.TrimSpace(d.Date),
+		Year:       firstYear(d.Date),
+		Edition:    strings.TrimSpace(d.Edition),
+		Language:   strings.TrimSpace(d.Language),
+		OwnerCount: r.holdingsCount(),
+		Record:     r,
+	}
+	if len(d.NCID) > 0 {
+		data.URL = NCID(d.NCID).URL()
+	}
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			data.Authors = append(data.Authors, TemplateAuthor{author[0], author[1], author[2]})
+		}
+	}
+	data.Publishers, _ = r.Publishers()
+	if isbns, ok := r.ISBNs(); ok {
+		for _, isbn := range isbns {
+			data.ISBNs = append(data.ISBNs, string(isbn))
+		}
+	}
+	data.ISSNs, _ = r.ISSNs()
+	data.Series, _ = r.SeriesInfo()
+	data.Topics, _ = r.Topics()
+	data.Notes, _ = r.Notes()
+	if holdings, ok := r.Holdings(); ok {
+		for _, holding := range holdings {
+			data.Holdings = append(data.Holdings, TemplateHolding{holding[0], holding[1], holding[2]})
+		}
+	}
+	return data
+}
+
+// FormatRecord はレコードのTemplateDataをテンプレートに適用した文字列を返す関数。Descriptionのないレコードはエラーとする
+func FormatRecord(r *Record, tmpl *template.Template) (string, error) {
+	if len(r.Descriptions) == 0 {
+		return "", fmt.Errorf("cinii: no description")
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, r.TemplateData()); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,179 @@
+package cinii
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TitleReading はタイトルと読みの構造体
+type TitleReading struct {
+	Title   string
+	Reading string
+	Lang    string // タイトルの言語。指定がない場合は空
+}
+
+// isReadingLang は言語タグが読み（カナ等）を表すか判定する関数
+func isReadingLang(lang string) bool {
+	lang = strings.ToLower(lang)
+	return strings.Contains(lang, "kana") || strings.Contains(lang, "hira") || strings.Contains(lang, "hrkt")
+}
+
+// pairReadings はタイトル要素の並びからタイトルと読みの組を作る関数。
+// 要素の順序によらず、言語指定のないまたは日本語のタイトルと読みを出現順に対応付ける。
+// 対応するタイトルのない読みは読みのみの組とする
+func pairReadings(fields TextFields) (ret []TitleReading) {
+	var readings []string
+	for _, field := range fields {
+		text := strings.TrimSpace(field.Text)
+		if len(text) == 0 {
+			continue
+		}
+		if isReadingLang(field.Lang) {
+			readings = append(readings, text)
+			continue
+		}
+		ret = append(ret, TitleReading{Title: text, Lang: field.Lang})
+	}
+	for i := range ret {
+		if len(readings) == 0 {
+			return
+		}
+		if lang := strings.ToLower(ret[i].Lang); len(lang) == 0 || lang == "ja" || strings.HasPrefix(lang, "ja-") {
+			ret[i].Reading, readings = readings[0], readings[1:]
+		}
+	}
+	for _, reading := range readings {
+		ret = append(ret, TitleReading{Reading: reading})
+	}
+	return
+}
+
+// TitleWithReading はレコードから最初のタイトルとその読みを返すメソッド
+func (r *Record) TitleWithReading() (TitleReading, bool) {
+	if len(r.Descriptions) == 0 {
+		return TitleReading{}, false
+	}
+	titles := pairReadings(r.Descriptions[0].Title)
+	if len(titles) == 0 {
+		return TitleReading{}, false
+	}
+	return titles[0], true
+}
+
+// Title はレコードから言語が一致するタイトルを返すメソッド。langが空の場合は最初のタイトルを返す。
+// langに"ja-Kana"等の読みの言語を指定した場合はその読みを返す
+func (r *Record) Title(lang string) (string, bool) {
+	if len(r.Descriptions) == 0 {
+		return "", false
+	}
+	if len(lang) == 0 {
+		title, ok := r.TitleWithReading()
+		return title.Title, ok && len(title.Title) > 0
+	}
+	for _, field := range r.Descriptions[0].Title {
+		if strings.EqualFold(field.Lang, lang) && len(strings.TrimSpace(field.Text)) > 0 {
+			return strings.TrimSpace(field.Text), true
+		}
+	}
+	return "", false
+}
+
+// AlternativeTitles はレコードからその他のタイトルと読みの組の配列を返すメソッド
+func (r *Record) AlternativeTitles() (ret []TitleReading, ok bool) {
+	if len(r.Descriptions) == 0 {
+		return nil, false
+	}
+	ret = pairReadings(r.Descriptions[0].Alternative)
+	return ret, len(ret) > 0
+}
+
+// partNumberPattern はタイトル末尾の巻次等のパターン。"上"、"第2巻"、"v.1"、"(3)"等
+var partNumberPattern = regexp.MustCompile(`\s+(上|中|下|前編|中編|後編|続|正|続編|別巻|第[0-9０-９一二三四五六七八九十百]+[巻編部冊集号輯]|[0-9０-９]+|[vV]\.\s*[0-9]+|[（(][0-9０-９]+[)）])$`)
+
+// TitleStatement はタイトルと責任表示の構造体
+type TitleStatement struct {
+	Title          string // 本タイトル
+	ParallelTitle  string // 並列タイトル
+	Subtitle       string // タイトル関連情報（副題）
+	PartNumber     string // 部編番号（"上"、"第2巻"等）
+	PartName       string // 部編名
+	Responsibility string // 責任表示
+}
+
+// Stringerインターフェースの実装。ISBDの区切り記号で組み立てた文字列を返す
+func (t TitleStatement) String() string {
+	s := t.Title
+	if len(t.PartNumber) > 0 || len(t.PartName) > 0 {
+		part := t.PartNumber
+		if len(t.PartName) > 0 {
+			if len(part) > 0 {
+				part += ", "
+			}
+			part += t.PartName
+		}
+		s += ". " + part
+	}
+	if len(t.ParallelTitle) > 0 {
+		s += " = " + t.ParallelTitle
+	}
+	if len(t.Subtitle) > 0 {
+		s += " : " + t.Subtitle
+	}
+	if len(t.Responsibility) > 0 {
+		s += " / " + t.Responsibility
+	}
+	return s
+}
+
+// cutSeparator は文字列を最初の区切りの前後に分割する関数。区切りがない場合はfalseを返す
+func cutSeparator(s string, seps ...string) (before, after string, found bool) {
+	for _, sep := range seps {
+		if i := strings.Index(s, sep); i >= 0 {
+			return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(sep):]), true
+		}
+	}
+	return s, "", false
+}
+
+// ParseTitleStatement は"本タイトル. 部編番号, 部編名 = 並列タイトル : 副題 / 責任表示"形式のタイトルを分割する関数。
+// 区切りのない"○○ 上"のような末尾の巻次は部編番号とする
+func ParseTitleStatement(s string) TitleStatement {
+	var t TitleStatement
+	s, t.Responsibility, _ = cutSeparator(strings.TrimSpace(s), " / ", "／")
+	s, t.Subtitle, _ = cutSeparator(s, " : ", "：")
+	s, t.ParallelTitle, _ = cutSeparator(s, " = ", "＝")
+
+	title, part, found := cutSeparator(s, ". ", "．")
+	t.Title = title
+	if found {
+		number, name, _ := cutSeparator(part, ", ", "，")
+		if partNumberPattern.MatchString(" " + number) {
+			t.PartNumber, t.PartName = number, name
+		} else {
+			t.PartName = part
+		}
+		return t
+	}
+	if m := partNumberPattern.FindStringSubmatchIndex(t.Title); m != nil && m[0] > 0 {
+		t.PartNumber = t.Title[m[2]:m[3]]
+		t.Title = strings.TrimSpace(t.Title[:m[0]])
+	} else if m := partNumberPattern.FindStringSubmatchIndex(t.Subtitle); m != nil && m[0] > 0 {
+		t.PartNumber = t.Subtitle[m[2]:m[3]]
+		t.Subtitle = strings.TrimSpace(t.Subtitle[:m[0]])
+	}
+	return t
+}
+
+// TitleStatement はレコードの最初のタイトルを分割して返すメソッド。
+// タイトルに責任表示がない場合はdc:creatorを責任表示とする
+func (r *Record) TitleStatement() (TitleStatement, bool) {
+	title, ok := r.TitleWithReading()
+	if !ok || len(title.Title) == 0 {
+		return TitleStatement{}, false
+	}
+	t := ParseTitleStatement(title.Title)
+	if len(t.Responsibility) == 0 {
+		t.Responsibility = strings.TrimSpace(r.Descriptions[0].Creator)
+	}
+	return t, true
+}
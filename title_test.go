@@ -0,0 +1,70 @@
+package cinii
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPairReadings(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields TextFields
+		want   []TitleReading
+	}{
+		{
+			"reading after title",
+			TextFields{{Text: "吾輩は猫である"}, {Lang: "ja-Kana", Text: "ワガハイ ワ ネコ デアル"}},
+			[]TitleReading{{Title: "吾輩は猫である", Reading: "ワガハイ ワ ネコ デアル"}},
+		},
+		{
+			"reading before title",
+			TextFields{{Lang: "ja-Kana", Text: "ワガハイ ワ ネコ デアル"}, {Text: "吾輩は猫である"}},
+			[]TitleReading{{Title: "吾輩は猫である", Reading: "ワガハイ ワ ネコ デアル"}},
+		},
+		{
+			"english title is not paired",
+			TextFields{{Lang: "en", Text: "I am a cat"}, {Lang: "ja-Kana", Text: "ワガハイ ワ ネコ デアル"}, {Text: "吾輩は猫である"}},
+			[]TitleReading{{Title: "I am a cat", Lang: "en"}, {Title: "吾輩は猫である", Reading: "ワガハイ ワ ネコ デアル"}},
+		},
+		{
+			"more than two titles",
+			TextFields{{Text: "こころ"}, {Lang: "ja-Kana", Text: "ココロ"}, {Text: "坊っちゃん"}, {Lang: "ja-Kana", Text: "ボッチャン"}},
+			[]TitleReading{{Title: "こころ", Reading: "ココロ"}, {Title: "坊っちゃん", Reading: "ボッチャン"}},
+		},
+		{
+			"reading only",
+			TextFields{{Lang: "ja-Kana", Text: "ココロ"}},
+			[]TitleReading{{Reading: "ココロ"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pairReadings(tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pairReadings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordTitle(t *testing.T) {
+	r := &Record{Descriptions: []Description{{Title: TextFields{
+		{Lang: "ja-Kana", Text: "ワガハイ ワ ネコ デアル"},
+		{Text: "吾輩は猫である"},
+		{Lang: "en", Text: "I am a cat"},
+	}}}}
+	tests := []struct {
+		lang string
+		want string
+		ok   bool
+	}{
+		{"", "吾輩は猫である", true},
+		{"en", "I am a cat", true},
+		{"ja-kana", "ワガハイ ワ ネコ デアル", true},
+		{"fr", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := r.Title(tt.lang); got != tt.want || ok != tt.ok {
+			t.Errorf("Title(%q) = %q, %v, want %q, %v", tt.lang, got, ok, tt.want, tt.ok)
+		}
+	}
+}
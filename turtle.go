@@ -0,0 +1,473 @@
+package cinii
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// GetTurtle はレコードIDを受け取り、Turtle形式で取得した情報をRecord構造体のポインタで返す関数
+func GetTurtle(url string, appid string, opts ...ParseOption) (*Record, error) {
+	u, err := recordURL(url)
+	if err != nil {
+		return nil, err
+	}
+	// Turtleの解析はデータ全体を必要とするため一括で読み込む
+	body, err := fetch(u+".ttl", appid)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := ParseTurtle(body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ParseTurtle はTurtle形式のRecord情報を含むbyte[]を受け取りRecord構造体のポインタで返す関数
+func ParseTurtle(body []byte, opts ...ParseOption) (*Record, error) {
+	config := newParseConfig(opts)
+
+	p := newTurtleParser(string(body))
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	record := p.graph.record(config)
+	if err := config.check(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// turtleParser はTurtleをnodeGraphに変換する構造体
+type turtleParser struct {
+	src      string
+	pos      int
+	base     string
+	prefixes map[string]string
+	graph    *nodeGraph
+	blank    int
+}
+
+func newTurtleParser(src string) *turtleParser {
+	p := &turtleParser{src: src, prefixes: make(map[string]string), graph: newNodeGraph()}
+	for k, v := range defaultPrefixes {
+		p.prefixes[k] = v
+	}
+	return p
+}
+
+func (p *turtleParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("cinii: turtle: %s at offset %d", fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *turtleParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *turtleParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// skipSpace は空白とコメントを読み飛ばすメソッド
+func (p *turtleParser) skipSpace() {
+	for !p.eof() {
+		switch c := p.src[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		case c == '#':
+			for !p.eof() && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// expect は次の文字が期待した文字であることを確認するメソッド
+func (p *turtleParser) expect(c byte) error {
+	p.skipSpace()
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+// keyword は大文字小文字を区別せずにキーワードを読み込むメソッド
+func (p *turtleParser) keyword(kw string) bool {
+	end := p.pos + len(kw)
+	if end > len(p.src) || !strings.EqualFold(p.src[p.pos:end], kw) {
+		return false
+	}
+	if end < len(p.src) && !isTurtleDelim(p.src[end]) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *turtleParser) parse() error {
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return nil
+		}
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], "@prefix"):
+			p.pos += len("@prefix")
+			if err := p.prefix(); err != nil {
+				return err
+			}
+			if err := p.expect('.'); err != nil {
+				return err
+			}
+		case strings.HasPrefix(p.src[p.pos:], "@base"):
+			p.pos += len("@base")
+			if err := p.baseIRI(); err != nil {
+				return err
+			}
+			if err := p.expect('.'); err != nil {
+				return err
+			}
+		case p.keyword("PREFIX"):
+			if err := p.prefix(); err != nil {
+				return err
+			}
+		case p.keyword("BASE"):
+			if err := p.baseIRI(); err != nil {
+				return err
+			}
+		default:
+			if err := p.triples(); err != nil {
+				return err
+			}
+			if err := p.expect('.'); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// prefix は接頭辞の定義を読み込むメソッド
+func (p *turtleParser) prefix() error {
+	p.skipSpace()
+	start := p.pos
+	for !p.eof() && p.src[p.pos] != ':' {
+		p.pos++
+	}
+	if p.eof() {
+		return p.errorf("unterminated prefix name")
+	}
+	name := strings.TrimSpace(p.src[start:p.pos])
+	p.pos++
+	p.skipSpace()
+	iri, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+	p.prefixes[name] = iri
+	return nil
+}
+
+// baseIRI はベースIRIの定義を読み込むメソッド
+func (p *turtleParser) baseIRI() error {
+	p.skipSpace()
+	iri, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+	p.base = iri
+	return nil
+}
+
+// triples は主語と述語目的語リストを読み込むメソッド
+func (p *turtleParser) triples() error {
+	p.skipSpace()
+	var subject *node
+	if p.peek() == '[' {
+		p.pos++
+		subject = p.graph.get(p.newBlank())
+		p.skipSpace()
+		if p.peek() == ']' {
+			p.pos++
+		} else {
+			if err := p.predicateObjectList(subject); err != nil {
+				return err
+			}
+			if err := p.expect(']'); err != nil {
+				return err
+			}
+		}
+		p.skipSpace()
+		if p.peek() == '.' {
+			return nil
+		}
+	} else {
+		id, err := p.resource()
+		if err != nil {
+			return err
+		}
+		subject = p.graph.get(id)
+	}
+	return p.predicateObjectList(subject)
+}
+
+// predicateObjectList は述語目的語リストを読み込みノードに追加するメソッド
+func (p *turtleParser) predicateObjectList(n *node) error {
+	for {
+		p.skipSpace()
+		var pred string
+		if p.peek() == 'a' && p.pos+1 < len(p.src) && isTurtleDelim(p.src[p.pos+1]) {
+			p.pos++
+			pred = nsRDF + "type"
+		} else {
+			var err error
+			if pred, err = p.resource(); err != nil {
+				return err
+			}
+		}
+		for {
+			values, err := p.object()
+			if err != nil {
+				return err
+			}
+			for _, v := range values {
+				n.add(pred, v)
+			}
+			p.skipSpace()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		// 連続する';'は許される
+		if p.peek() != ';' {
+			return nil
+		}
+		for p.peek() == ';' {
+			p.pos++
+			p.skipSpace()
+		}
+		if c := p.peek(); c == '.' || c == ']' || c == 0 {
+			return nil
+		}
+	}
+}
+
+// object は目的語を読み込むメソッド。コレクションは展開して返す
+func (p *turtleParser) object() ([]nodeValue, error) {
+	p.skipSpace()
+	switch c := p.peek(); {
+	case c == '[':
+		p.pos++
+		n := newNode(p.newBlank())
+		p.skipSpace()
+		if p.peek() != ']' {
+			if err := p.predicateObjectList(n); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expect(']'); err != nil {
+			return nil, err
+		}
+		return []nodeValue{{IRI: n.id, Node: n}}, nil
+	case c == '(':
+		p.pos++
+		var values []nodeValue
+		for {
+			p.skipSpace()
+			if p.peek() == ')' {
+				p.pos++
+				return values, nil
+			}
+			if p.eof() {
+				return nil, p.errorf("unterminated collection")
+			}
+			v, err := p.object()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v...)
+		}
+	case c == '"' || c == '\'':
+		v, err := p.literal()
+		if err != nil {
+			return nil, err
+		}
+		return []nodeValue{v}, nil
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for !p.eof() && strings.IndexByte("0123456789.eE+-", p.src[p.pos]) >= 0 {
+			p.pos++
+		}
+		// 文末の'.'は数値に含めない
+		if p.src[p.pos-1] == '.' {
+			p.pos--
+		}
+		return []nodeValue{{Text: p.src[start:p.pos]}}, nil
+	case p.keyword("true"):
+		return []nodeValue{{Text: "true"}}, nil
+	case p.keyword("false"):
+		return []nodeValue{{Text: "false"}}, nil
+	}
+	iri, err := p.resource()
+	if err != nil {
+		return nil, err
+	}
+	return []nodeValue{{IRI: iri}}, nil
+}
+
+// resource はIRI、接頭辞付き名前、ブランクノードのいずれかを読み込むメソッド
+func (p *turtleParser) resource() (string, error) {
+	p.skipSpace()
+	if p.peek() == '<' {
+		return p.iriRef()
+	}
+	start := p.pos
+	for !p.eof() && !isTurtleDelim(p.src[p.pos]) {
+		p.pos++
+	}
+	// 文末の'.'は名前に含めない
+	for p.pos > start && p.src[p.pos-1] == '.' {
+		p.pos--
+	}
+	name := p.src[start:p.pos]
+	if len(name) == 0 {
+		return "", p.errorf("expected IRI")
+	}
+	if strings.HasPrefix(name, "_:") {
+		return name, nil
+	}
+	i := strings.Index(name, ":")
+	if i < 0 {
+		return "", p.errorf("invalid prefixed name %q", name)
+	}
+	ns, ok := p.prefixes[name[:i]]
+	if !ok {
+		return "", p.errorf("undefined prefix %q", name[:i])
+	}
+	return ns + strings.Replace(name[i+1:], "\\", "", -1), nil
+}
+
+// iriRef は<>で囲まれたIRIを読み込むメソッド
+func (p *turtleParser) iriRef() (string, error) {
+	if p.peek() != '<' {
+		return "", p.errorf("expected '<'")
+	}
+	end := strings.IndexByte(p.src[p.pos:], '>')
+	if end < 0 {
+		return "", p.errorf("unterminated IRI")
+	}
+	iri := p.src[p.pos+1 : p.pos+end]
+	p.pos += end + 1
+	if len(p.base) > 0 && !strings.Contains(iri, ":") {
+		if base, err := url.Parse(p.base); err == nil {
+			if ref, err := url.Parse(iri); err == nil {
+				iri = base.ResolveReference(ref).String()
+			}
+		}
+	}
+	return iri, nil
+}
+
+// literal は文字列リテラルを言語タグ・データ型とともに読み込むメソッド
+func (p *turtleParser) literal() (nodeValue, error) {
+	quote := p.src[p.pos : p.pos+1]
+	if strings.HasPrefix(p.src[p.pos:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	p.pos += len(quote)
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return nodeValue{}, p.errorf("unterminated literal")
+		}
+		if strings.HasPrefix(p.src[p.pos:], quote) {
+			p.pos += len(quote)
+			break
+		}
+		c := p.src[p.pos]
+		if c != '\\' {
+			r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+			sb.WriteRune(r)
+			p.pos += size
+			continue
+		}
+		p.pos++
+		if p.eof() {
+			return nodeValue{}, p.errorf("unterminated escape")
+		}
+		switch e := p.src[p.pos]; e {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u', 'U':
+			size := 4
+			if e == 'U' {
+				size = 8
+			}
+			if p.pos+1+size > len(p.src) {
+				return nodeValue{}, p.errorf("invalid unicode escape")
+			}
+			r, err := strconv.ParseUint(p.src[p.pos+1:p.pos+1+size], 16, 32)
+			if err != nil {
+				return nodeValue{}, p.errorf("invalid unicode escape")
+			}
+			sb.WriteRune(rune(r))
+			p.pos += size
+		default:
+			sb.WriteByte(e)
+		}
+		p.pos++
+	}
+
+	v := nodeValue{Text: sb.String()}
+	switch {
+	case p.peek() == '@':
+		start := p.pos + 1
+		p.pos++
+		for !p.eof() && !isTurtleDelim(p.src[p.pos]) {
+			p.pos++
+		}
+		for p.pos > start && p.src[p.pos-1] == '.' {
+			p.pos--
+		}
+		v.Lang = p.src[start:p.pos]
+	case strings.HasPrefix(p.src[p.pos:], "^^"):
+		p.pos += 2
+		// データ型は読み飛ばす
+		if _, err := p.resource(); err != nil {
+			return nodeValue{}, err
+		}
+	}
+	return v, nil
+}
+
+// newBlank は新しいブランクノードIDを返すメソッド
+func (p *turtleParser) newBlank() string {
+	p.blank++
+	return fmt.Sprintf("_:genid%d", p.blank)
+}
+
+// isTurtleDelim は名前の区切り文字か判定する関数
+func isTurtleDelim(c byte) bool {
+	return strings.IndexByte(" \t\r\n;,()[]<>\"'#", c) >= 0
+}
@@ -0,0 +1,66 @@
+package cinii
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Finding はValidateで検出した問題の構造体
+type Finding struct {
+	Field   string
+	Message string
+}
+
+// Stringerインターフェースの実装
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Field, f.Message)
+}
+
+// Validate はレコードで欠けている項目を検査し、検出した問題の配列を返すメソッド
+func (r *Record) Validate() (findings []Finding) {
+	if len(r.Descriptions) == 0 {
+		return []Finding{{"Description", "no description"}}
+	}
+	d := r.Descriptions[0]
+
+	var title, reading bool
+	for _, t := range d.Title {
+		if len(strings.TrimSpace(t.Text)) == 0 {
+			continue
+		}
+		if isReadingLang(t.Lang) {
+			reading = true
+		} else {
+			title = true
+		}
+	}
+	if !title {
+		findings = append(findings, Finding{"Title", "no title"})
+	}
+	if !reading {
+		findings = append(findings, Finding{"TitleReading", "no title reading"})
+	}
+	if len(strings.TrimSpace(d.NCID)) == 0 {
+		findings = append(findings, Finding{"NCID", "no NCID"})
+	}
+	if len(d.Publisher) == 0 {
+		findings = append(findings, Finding{"Publisher", "no publisher"})
+	}
+	if date := strings.TrimSpace(d.Date); len(date) == 0 {
+		findings = append(findings, Finding{"Date", "no publication date"})
+	} else if _, ok := ParseDate(date); !ok {
+		findings = append(findings, Finding{"Date", fmt.Sprintf("malformed publication date: %s", date)})
+	}
+
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			if len(author[1]) == 0 {
+				findings = append(findings, Finding{"AuthorReading", fmt.Sprintf("no reading for %s", author[0])})
+			}
+		}
+	}
+	if _, ok := r.Holdings(); !ok {
+		findings = append(findings, Finding{"Holdings", "no holdings"})
+	}
+	return
+}
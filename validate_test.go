@@ -0,0 +1,33 @@
+package cinii
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateTitleReading(t *testing.T) {
+	tests := []struct {
+		name   string
+		titles TextFields
+		want   []Finding
+	}{
+		{"title and reading", TextFields{{Text: "吾輩は猫である"}, {Lang: "ja-Kana", Text: "ワガハイ ワ ネコ デアル"}}, nil},
+		{"translated title is not a reading", TextFields{{Text: "吾輩は猫である"}, {Lang: "en", Text: "I am a cat"}}, []Finding{{"TitleReading", "no title reading"}}},
+		{"translated title only", TextFields{{Lang: "en", Text: "I am a cat"}}, []Finding{{"TitleReading", "no title reading"}}},
+		{"reading only", TextFields{{Lang: "ja-Hrkt", Text: "ワガハイ ワ ネコ デアル"}}, []Finding{{"Title", "no title"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Record{Descriptions: []Description{{Title: tt.titles, NCID: "BA12345678", Publisher: []string{"岩波書店"}, Date: "1990"}}}
+			var got []Finding
+			for _, f := range r.Validate() {
+				if f.Field == "Title" || f.Field == "TitleReading" {
+					got = append(got, f)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package cinii
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// era は元号の構造体
+type era struct {
+	name  string
+	abbr  string // ローマ字の略号
+	start int    // 元年の西暦年
+}
+
+// eras は明治以降の元号
+var eras = []era{
+	{"明治", "M", 1868},
+	{"大正", "T", 1912},
+	{"昭和", "S", 1926},
+	{"平成", "H", 1989},
+	{"令和", "R", 2019},
+}
+
+var (
+	// warekiPattern は和暦年のパターン。"昭和52年", "S52", "平成元年"等
+	warekiPattern = regexp.MustCompile(`(明治|大正|昭和|平成|令和|[MTSHR]\.?)\s*([0-9]{1,2}|元)`)
+	// kanjiEraPattern は漢数字で書かれた和暦年のパターン
+	kanjiEraPattern = regexp.MustCompile(`(明治|大正|昭和|平成|令和)([〇一二三四五六七八九十]+|元)`)
+	// bareNumberPattern は元号を省略した範囲の終わりの年のパターン。"昭和52-55"の"55"等
+	bareNumberPattern = regexp.MustCompile(`^\s*([0-9]{1,2})(?:[^0-9]|$)`)
+)
+
+// rangeSeparators は年の範囲の区切り
+var rangeSeparators = strings.NewReplacer("－", "-", "〜", "-", "～", "-", "~", "-", "‐", "-", "―", "-")
+
+// YearRange は西暦年の範囲の構造体。単年の場合はFromとToが等しい
+type YearRange struct {
+	From int
+	To   int
+}
+
+// Stringerインターフェースの実装。単年の場合は"1977"、範囲の場合は"1977-1980"を返す
+func (y YearRange) String() string {
+	if y.From == y.To {
+		return strconv.Itoa(y.From)
+	}
+	return strconv.Itoa(y.From) + "-" + strconv.Itoa(y.To)
+}
+
+// Contains は西暦年が範囲に含まれるかを返すメソッド
+func (y YearRange) Contains(year int) bool {
+	return y.From <= year && year <= y.To
+}
+
+// toHalfWidthDigits は全角数字を半角数字に変換する関数
+func toHalfWidthDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if '０' <= r && r <= '９' {
+			return r - '０' + '0'
+		}
+		return r
+	}, s)
+}
+
+// kanjiNumber は"五十二"等の99以下の漢数字を数値に変換する関数
+func kanjiNumber(s string) (int, bool) {
+	const digits = "〇一二三四五六七八九"
+	n, cur := 0, -1
+	for _, r := range s {
+		if r == '十' {
+			if cur < 0 {
+				cur = 1
+			}
+			n += cur * 10
+			cur = -1
+			continue
+		}
+		i := strings.IndexRune(digits, r)
+		if i < 0 {
+			return 0, false
+		}
+		if cur > 0 {
+			cur = cur*10 + i/len("〇")
+		} else {
+			cur = i / len("〇")
+		}
+	}
+	if cur > 0 {
+		n += cur
+	}
+	return n, n > 0
+}
+
+// eraOf は元号名または略号から元号を返す関数
+func eraOf(s string) (era, bool) {
+	s = strings.TrimSuffix(s, ".")
+	for _, e := range eras {
+		if s == e.name || s == e.abbr {
+			return e, true
+		}
+	}
+	return era{}, false
+}
+
+// WarekiYear は元号名（"昭和"）または略号（"S"）と年を受け取り、西暦年を返す関数
+func WarekiYear(name string, year int) (int, bool) {
+	e, ok := eraOf(name)
+	if !ok || year < 1 {
+		return 0, false
+	}
+	return e.start + year - 1, true
+}
+
+// parseYear は範囲の一方の文字列から西暦年を返す関数。
+// 和暦年、西暦年の順に探し、どちらもない場合は直前の元号prevの年とみなす
+func parseYear(s string, prev *era) (int, bool) {
+	for _, m := range warekiPattern.FindAllStringSubmatchIndex(s, -1) {
+		name := s[m[2]:m[3]]
+		// 略号は英単語の一部（"ISBN"等）でない場合のみ
+		if len(name) <= 2 && m[0] > 0 {
+			if c := s[m[0]-1]; ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') {
+				continue
+			}
+		}
+		n := 1
+		if num := s[m[4]:m[5]]; num != "元" {
+			n, _ = strconv.Atoi(num)
+		}
+		e, _ := eraOf(name)
+		if year, ok := WarekiYear(name, n); ok {
+			*prev = e
+			return year, true
+		}
+	}
+	if y := yearPattern.FindString(s); len(y) > 0 {
+		year, _ := strconv.Atoi(y)
+		*prev = era{}
+		return year, true
+	}
+	if len(prev.name) > 0 {
+		if m := bareNumberPattern.FindStringSubmatch(s); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			return WarekiYear(prev.name, n)
+		}
+	}
+	return 0, false
+}
+
+// ParseYears は出版年等の文字列から西暦年の範囲を返す関数。
+// "1998-2003"のような西暦年、"昭和52年"、"S52"、"平成元年"、"昭和五十二年"のような和暦年、
+// "昭和52-55"のような元号を省略した範囲を扱う
+func ParseYears(s string) (ret YearRange, ok bool) {
+	s = toHalfWidthDigits(s)
+	s = kanjiEraPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := kanjiEraPattern.FindStringSubmatch(m)
+		if sub[2] == "元" {
+			return m
+		}
+		if n, ok := kanjiNumber(sub[2]); ok {
+			return sub[1] + strconv.Itoa(n)
+		}
+		return m
+	})
+	s = rangeSeparators.Replace(s)
+
+	var prev era
+	for _, part := range strings.Split(s, "-") {
+		year, found := parseYear(part, &prev)
+		if !found {
+			continue
+		}
+		if !ok {
+			ret = YearRange{year, year}
+			ok = true
+			continue
+		}
+		if year < ret.From {
+			ret.From = year
+		}
+		if year > ret.To {
+			ret.To = year
+		}
+	}
+	return ret, ok
+}
+
+// PublicationYears はレコードの出版年（dc:date）を西暦年の範囲で返すメソッド。
+// 出版年がない場合は出版事項中の年を用いる
+func (r *Record) PublicationYears() (YearRange, bool) {
+	if len(r.Descriptions) == 0 {
+		return YearRange{}, false
+	}
+	d := r.Descriptions[0]
+	if years, ok := ParseYears(d.Date); ok {
+		return years, true
+	}
+	for _, p := range d.Publisher {
+		if years, ok := ParseYears(p); ok {
+			return years, true
+		}
+	}
+	return YearRange{}, false
+}
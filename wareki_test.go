@@ -0,0 +1,100 @@
+package cinii
+
+import "testing"
+
+func TestWarekiYear(t *testing.T) {
+	tests := []struct {
+		name string
+		year int
+		want int
+		ok   bool
+	}{
+		{"明治", 1, 1868, true},
+		{"明治", 45, 1912, true},
+		{"大正", 1, 1912, true},
+		{"大正", 15, 1926, true},
+		{"昭和", 1, 1926, true},
+		{"昭和", 64, 1989, true},
+		{"平成", 1, 1989, true},
+		{"平成", 31, 2019, true},
+		{"令和", 1, 2019, true},
+		{"S", 52, 1977, true},
+		{"H.", 10, 1998, true},
+		{"R", 5, 2023, true},
+		{"昭和", 0, 0, false},
+		{"慶応", 3, 0, false},
+		{"X", 1, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := WarekiYear(tt.name, tt.year)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("WarekiYear(%q, %d) = %d, %v, want %d, %v", tt.name, tt.year, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseYears(t *testing.T) {
+	tests := []struct {
+		in   string
+		want YearRange
+		ok   bool
+	}{
+		{"昭和52年", YearRange{1977, 1977}, true},
+		{"S52", YearRange{1977, 1977}, true},
+		{"S.52", YearRange{1977, 1977}, true},
+		{"明治元年", YearRange{1868, 1868}, true},
+		{"大正元年", YearRange{1912, 1912}, true},
+		{"昭和元年", YearRange{1926, 1926}, true},
+		{"平成元年", YearRange{1989, 1989}, true},
+		{"令和元年", YearRange{2019, 2019}, true},
+		{"H元", YearRange{1989, 1989}, true},
+		{"昭和64年", YearRange{1989, 1989}, true},
+		{"平成31年", YearRange{2019, 2019}, true},
+		{"昭和五十二年", YearRange{1977, 1977}, true},
+		{"平成十年", YearRange{1998, 1998}, true},
+		{"昭和２０年", YearRange{1945, 1945}, true},
+		{"昭和52-55", YearRange{1977, 1980}, true},
+		{"昭和52年〜55年", YearRange{1977, 1980}, true},
+		{"平成30-令和2", YearRange{2018, 2020}, true},
+		{"M.45-T.2", YearRange{1912, 1913}, true},
+		{"1998-2003", YearRange{1998, 2003}, true},
+		{"１９９８－２００３", YearRange{1998, 2003}, true},
+		{"東京 : 岩波書店, 1990", YearRange{1990, 1990}, true},
+		{"ISBN 2001", YearRange{2001, 2001}, true},
+		{"", YearRange{}, false},
+		{"n.d.", YearRange{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseYears(tt.in)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParseYears(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPublicationYears(t *testing.T) {
+	tests := []struct {
+		name string
+		desc Description
+		want YearRange
+		ok   bool
+	}{
+		{"date", Description{Date: "昭和52", Publisher: []string{"東京 : 岩波書店, 1990"}}, YearRange{1977, 1977}, true},
+		{"publisher", Description{Publisher: []string{"東京 : 岩波書店", "平成元年"}}, YearRange{1989, 1989}, true},
+		{"none", Description{Publisher: []string{"東京 : 岩波書店"}}, YearRange{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Record{Descriptions: []Description{tt.desc}}
+			got, ok := r.PublicationYears()
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("PublicationYears() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+	if _, ok := (&Record{}).PublicationYears(); ok {
+		t.Error("empty record PublicationYears() ok = true")
+	}
+}
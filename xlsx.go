@@ -0,0 +1,154 @@
+package cinii
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xlsxPart はxlsx（ZIP）に格納するパーツの構造体
+type xlsxPart struct {
+	name    string
+	content string
+}
+
+// xlsxStaticParts はブックの内容によらないxlsxのパーツ
+var xlsxStaticParts = []xlsxPart{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/worksheets/sheet2.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+<sheet name="Records" sheetId="1" r:id="rId1"/>
+<sheet name="Holdings" sheetId="2" r:id="rId2"/>
+</sheets>
+</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`},
+	// スタイル1は見出し行（太字、背景色、罫線）
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFD9E1F2"/><bgColor indexed="64"/></patternFill></fill></fills>
+<borders count="2"><border><left/><right/><top/><bottom/><diagonal/></border><border><left/><right/><top/><bottom style="thin"><color auto="1"/></bottom><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="2" borderId="1" xfId="0" applyFont="1" applyFill="1" applyBorder="1"/></cellXfs>
+</styleSheet>`},
+}
+
+// xlsxColumnName は0から始まる列番号をA, B, ..., Z, AA, ...の列名に変換する関数
+func xlsxColumnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxSheet はワークシートのXMLを組み立てる構造体
+type xlsxSheet struct {
+	sb   strings.Builder
+	rows int
+}
+
+// row は行を追加するメソッド。見出し行はスタイル1とし、numericがtrueの列で数値に変換できる値は数値とする
+func (s *xlsxSheet) row(values []string, header bool, numeric func(col int) bool) {
+	s.rows++
+	fmt.Fprintf(&s.sb, `<row r="%d">`, s.rows)
+	for i, v := range values {
+		ref := xlsxColumnName(i) + strconv.Itoa(s.rows)
+		switch {
+		case header:
+			fmt.Fprintf(&s.sb, `<c r="%s" s="1" t="inlineStr"><is><t>`, ref)
+		case numeric != nil && numeric(i) && isDigits(v):
+			fmt.Fprintf(&s.sb, `<c r="%s"><v>%s</v></c>`, ref, v)
+			continue
+		default:
+			fmt.Fprintf(&s.sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		}
+		xml.EscapeText(&s.sb, []byte(v))
+		s.sb.WriteString("</t></is></c>")
+	}
+	s.sb.WriteString("</row>\n")
+}
+
+// String はワークシートのXMLを返すメソッド。見出し行は固定する
+func (s *xlsxSheet) String() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>
+<sheetData>
+` + s.sb.String() + `</sheetData>
+</worksheet>`
+}
+
+// holdingsColumns は所蔵シートの列名
+var holdingsColumns = []string{"ncid", "library", "faid", "opac_url"}
+
+// WriteXLSX はレコードの配列を書誌シートと所蔵シートからなるxlsxブックで書き出す関数。
+// 書誌シートの列を省略した場合はDefaultColumnsとする。nilとDescriptionのないレコードは除く
+func WriteXLSX(w io.Writer, records []*Record, columns ...Column) error {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+
+	bib := &xlsxSheet{}
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.String()
+	}
+	bib.row(header, true, nil)
+	numeric := func(col int) bool {
+		return columns[col] == ColumnOwnerCount || columns[col] == ColumnYear
+	}
+
+	holdings := &xlsxSheet{}
+	holdings.row(holdingsColumns, true, nil)
+
+	for _, record := range records {
+		if record == nil || len(record.Descriptions) == 0 {
+			continue
+		}
+		bib.row(record.Row(columns...), false, numeric)
+		if hs, ok := record.Holdings(); ok {
+			ncid := record.Descriptions[0].NCID
+			for _, h := range hs {
+				holdings.row([]string{ncid, h[0], h[1], h[2]}, false, nil)
+			}
+		}
+	}
+
+	zw := zip.NewWriter(w)
+	parts := append(append([]xlsxPart{}, xlsxStaticParts...),
+		xlsxPart{"xl/worksheets/sheet1.xml", bib.String()},
+		xlsxPart{"xl/worksheets/sheet2.xml", holdings.String()},
+	)
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
@@ -0,0 +1,198 @@
+package cinii
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// zoteroHeader はZotero RDFのrdf:RDF要素の開始タグ
+const zoteroHeader = `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"` +
+	` xmlns:z="http://www.zotero.org/namespaces/export#"` +
+	` xmlns:dc="http://purl.org/dc/elements/1.1/"` +
+	` xmlns:dcterms="http://purl.org/dc/terms/"` +
+	` xmlns:bib="http://purl.org/net/biblio#"` +
+	` xmlns:foaf="http://xmlns.com/foaf/0.1/"` +
+	` xmlns:prism="http://prismstandard.org/namespaces/1.2/basic/"` +
+	` xmlns:vcard="http://nwalsh.com/rdf/vCard#">`
+
+// zoteroItem はZotero RDFの項目の構造体
+type zoteroItem struct {
+	about     string
+	title     string
+	authors   []CSLName
+	publisher Publisher
+	date      string
+	edition   string
+	language  string
+	series    []string
+	subjects  []string
+	isbns     []string
+	issns     []string
+	extra     []string // "Extra"欄の各行
+}
+
+// zoteroWriter はZotero RDFを組み立てる構造体
+type zoteroWriter struct {
+	sb strings.Builder
+}
+
+// element は値が空でなければ要素を追加するメソッド
+func (w *zoteroWriter) element(indent, name, value string) {
+	if value = strings.TrimSpace(value); len(value) == 0 {
+		return
+	}
+	w.sb.WriteString(indent + "<" + name + ">")
+	xml.EscapeText(&w.sb, []byte(value))
+	w.sb.WriteString("</" + name + ">\n")
+}
+
+// item は項目を1つ追加するメソッド
+func (w *zoteroWriter) item(it zoteroItem) {
+	w.sb.WriteString(`  <bib:Book rdf:about="`)
+	xml.EscapeText(&w.sb, []byte(it.about))
+	w.sb.WriteString("\">\n")
+	w.element("    ", "z:itemType", "book")
+	if len(it.publisher.Name) > 0 || len(it.publisher.Place) > 0 {
+		w.sb.WriteString("    <dc:publisher>\n      <foaf:Organization>\n")
+		if len(it.publisher.Place) > 0 {
+			w.sb.WriteString("        <vcard:adr>\n          <vcard:Address>\n")
+			w.element("            ", "vcard:locality", it.publisher.Place)
+			w.sb.WriteString("          </vcard:Address>\n        </vcard:adr>\n")
+		}
+		w.element("        ", "foaf:name", it.publisher.Name)
+		w.sb.WriteString("      </foaf:Organization>\n    </dc:publisher>\n")
+	}
+	if len(it.authors) > 0 {
+		w.sb.WriteString("    <bib:authors>\n      <rdf:Seq>\n")
+		for _, name := range it.authors {
+			w.sb.WriteString("        <rdf:li>\n          <foaf:Person>\n")
+			if len(name.Literal) > 0 {
+				w.element("            ", "foaf:surname", name.Literal)
+			} else {
+				w.element("            ", "foaf:surname", name.Family)
+				w.element("            ", "foaf:givenName", name.Given)
+			}
+			w.sb.WriteString("          </foaf:Person>\n        </rdf:li>\n")
+		}
+		w.sb.WriteString("      </rdf:Seq>\n    </bib:authors>\n")
+	}
+	for _, series := range it.series {
+		w.sb.WriteString("    <dcterms:isPartOf>\n      <bib:Series>\n")
+		w.element("        ", "dc:title", series)
+		w.sb.WriteString("      </bib:Series>\n    </dcterms:isPartOf>\n")
+	}
+	for _, subject := range it.subjects {
+		w.element("    ", "dc:subject", subject)
+	}
+	for _, isbn := range it.isbns {
+		w.element("    ", "dc:identifier", "ISBN "+isbn)
+	}
+	for _, issn := range it.issns {
+		w.element("    ", "dc:identifier", "ISSN "+issn)
+	}
+	if strings.Contains(it.about, "://") {
+		w.sb.WriteString("    <dc:identifier>\n      <dcterms:URI>\n")
+		w.element("        ", "rdf:value", it.about)
+		w.sb.WriteString("      </dcterms:URI>\n    </dc:identifier>\n")
+	}
+	w.element("    ", "prism:edition", it.edition)
+	w.element("    ", "dc:date", it.date)
+	w.element("    ", "z:language", it.language)
+	w.element("    ", "dc:title", it.title)
+	// ZoteroはExtra欄をdc:descriptionとして読み込む
+	w.element("    ", "dc:description", strings.Join(it.extra, "\n"))
+	w.sb.WriteString("  </bib:Book>\n")
+}
+
+func (w *zoteroWriter) String() string {
+	return zoteroHeader + "\n" + w.sb.String() + "</rdf:RDF>\n"
+}
+
+// zoteroItem はレコードをZotero RDFの項目に変換するメソッド。
+// タイトルと著者の読みはExtra欄に格納する
+func (r *Record) zoteroItem() zoteroItem {
+	d := r.Descriptions[0]
+	it := zoteroItem{
+		about:    strings.TrimSpace(d.About),
+		date:     d.Date,
+		edition:  d.Edition,
+		language: d.Language,
+	}
+	if len(d.NCID) > 0 {
+		it.about = NCID(d.NCID).URL()
+		it.extra = append(it.extra, "NCID: "+d.NCID)
+	}
+
+	title, _ := r.TitleWithReading()
+	it.title = title.Title
+	if len(title.Reading) > 0 {
+		it.extra = append(it.extra, "Title Reading: "+title.Reading)
+	}
+	if authors, ok := r.Authors(); ok {
+		for _, author := range authors {
+			it.authors = append(it.authors, cslName(author[0]))
+			if len(author[1]) > 0 {
+				it.extra = append(it.extra, "Author Reading: "+author[0]+" = "+author[1])
+			}
+		}
+	}
+	if publishers, ok := r.Publishers(); ok {
+		it.publisher = publishers[0]
+	}
+	if series, ok := r.SeriesInfo(); ok {
+		for _, s := range series {
+			it.series = append(it.series, s.Title)
+		}
+	}
+	it.subjects, _ = r.Topics()
+	if isbns, ok := r.ISBNs(); ok {
+		for _, isbn := range isbns {
+			it.isbns = append(it.isbns, string(isbn))
+		}
+	}
+	it.issns, _ = r.ISSNs()
+	return it
+}
+
+// zoteroItem は検索結果のエントリをZotero RDFの項目に変換するメソッド
+func (e *Entry) zoteroItem() zoteroItem {
+	it := zoteroItem{
+		about:     e.ID,
+		title:     e.Title,
+		publisher: ParsePublisher(e.Publisher),
+		date:      e.PubDate,
+	}
+	for _, author := range e.Authors {
+		it.authors = append(it.authors, cslName(author.Name))
+	}
+	for _, part := range e.IsPartOf {
+		it.series = append(it.series, part.Title)
+	}
+	isbns, _ := e.ISBNs()
+	for _, isbn := range isbns {
+		it.isbns = append(it.isbns, string(isbn))
+	}
+	it.issns, _ = e.ISSNs()
+	return it
+}
+
+// ToZoteroRDF はレコードの配列をZoteroで読み込めるRDFに変換する関数。nilとDescriptionのないレコードは除く
+func ToZoteroRDF(records ...*Record) string {
+	w := &zoteroWriter{}
+	for _, record := range records {
+		if record == nil || len(record.Descriptions) == 0 {
+			continue
+		}
+		w.item(record.zoteroItem())
+	}
+	return w.String()
+}
+
+// ToZoteroRDF は検索結果のエントリをZoteroで読み込めるRDFに変換するメソッド
+func (f *AtomFeed) ToZoteroRDF() string {
+	w := &zoteroWriter{}
+	for i := range f.Entries {
+		w.item(f.Entries[i].zoteroItem())
+	}
+	return w.String()
+}